@@ -0,0 +1,8 @@
+//go:build cgo
+
+package cmd
+
+import "gitlab.com/monokuro/era/parser"
+
+// Handler behind the 'strftime', 'strptime' and 'c' formatters which calls the C library
+var strftimeHandler = &parser.CStr
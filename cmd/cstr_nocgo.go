@@ -0,0 +1,9 @@
+//go:build !cgo
+
+package cmd
+
+import "gitlab.com/monokuro/era/parser"
+
+// Handler behind the 'strftime', 'strptime' and 'c' formatters which without cgo falls
+// back to the Go port of strptime
+var strftimeHandler = &parser.GoStrptime
@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gitlab.com/monokuro/era/localiser"
+
+	"github.com/go-playground/locales/en_GB"
+	"github.com/spf13/cobra"
+)
+
+// Columns of CSV or TSV input to convert by name or 1-based index
+var Columns []string
+
+// Whether the first row of CSV or TSV input is a header when columns are given by index
+var Header bool
+
+// Separator between the columns of CSV or TSV input
+var Delimiter string
+
+func init() {
+	csvCmd.Flags().StringSliceVarP(&Columns, "column", "c", nil, "Columns to convert by header name or 1-based index, repeat or separate with commas for several")
+	csvCmd.Flags().StringVarP(&Delimiter, "delimiter", "d", ",", "Separator between columns, use 'tab' for TSV")
+	csvCmd.Flags().BoolVar(&Header, "header", false, "Copy the first row unchanged when columns are given by index, implied by column names")
+	csvCmd.Flags().StringVarP(&Format, "format", "f", "", "Format to display the converted datetimes with")
+	csvCmd.Flags().StringVarP(&Parser, "formatter", "F", "", "Formatter to interpret the datetimes with, by default the format of each is detected")
+	csvCmd.Flags().StringVarP(&TimeZone, "timezone", "t", "", "Time zone to set the times to")
+	csvCmd.Flags().StringVarP(&Locale, "locale", "l", "", "Locale to use in parsing and formatting")
+	csvCmd.Flags().StringVar(&Reference, "reference", "", "Date time the relative formatter describes times from e.g. '2025-05-09 12:00', by default now")
+	csvCmd.Flags().StringVar(&OnError, "on-error", "fail", "How to handle values that fail to parse: fail, skip, report or keep")
+	csvCmd.MarkFlagRequired("column")
+	rootCmd.AddCommand(csvCmd)
+}
+
+var csvCmd = &cobra.Command{
+	Use:   "csv [layout] [output layout]",
+	Short: "Convert datetime columns of CSV or TSV",
+	Long:  "Convert the datetimes in columns of CSV or TSV read from stdin to another format and time zone, writing every row to stdout\n\nOnly converted values are rewritten so the quoting, spacing and line endings of the input are preserved. Rows with values that fail to parse are stopped at (fail), dropped (skip), dropped and reported on stderr (report) or output with the value unchanged (keep)",
+	Args:  cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		location := time.Now().Local().Location()
+		locale := en_GB.New()
+
+		if TimeZone != "" {
+			loc, err := time.LoadLocation(TimeZone)
+			if err != nil {
+				return err
+			}
+			location = loc
+		}
+
+		if Locale != "" {
+			parsedLocale, err := localiser.Parse(Locale)
+			if err != nil {
+				return err
+			}
+			locale = parsedLocale
+		}
+
+		layout := ""
+		if len(args) > 0 {
+			layout = args[0]
+		}
+
+		// A second argument allows the output format to differ from the parsed format
+		parseStr := layout
+		if len(args) > 1 {
+			parseStr = args[1]
+		}
+		formatter := outputFormatter(layout)
+
+		// Reporting the format of every value would drown out the output
+		reportDetected = false
+		// The usage isn't relevant to values that fail to parse
+		cmd.SilenceUsage = true
+
+		return convertColumns(os.Stdin, os.Stdout, func(value string) (string, error) {
+			dt, err := ParseTime(value, Parser, layout, locale, location)
+			if err != nil {
+				return "", err
+			}
+			return FormatTime(dt, locale, formatter, parseStr)
+		})
+	},
+}
+
+// Field of a delimited row keeping the original text so unchanged fields are written
+// back exactly as they were read
+type csvField struct {
+	raw    string
+	value  string
+	quoted bool
+}
+
+// Converts the values of `--column` in each row of the input writing every row to
+// the output following `--on-error` for values that fail to convert
+func convertColumns(input io.Reader, output io.Writer, convert func(string) (string, error)) error {
+	switch OnError {
+	case "fail", "skip", "report", "keep":
+	default:
+		return fmt.Errorf("%q is not a supported --on-error mode, use fail, skip, report or keep", OnError)
+	}
+
+	comma, err := parseDelimiter()
+	if err != nil {
+		return err
+	}
+	delimiter := string(comma)
+
+	// Columns given by name are looked up in the header on the first row
+	columns := []int{}
+	hasHeader := Header
+	for _, column := range Columns {
+		if idx, err := strconv.Atoi(column); err == nil && idx > 0 {
+			columns = append(columns, idx-1)
+		} else {
+			hasHeader = true
+		}
+	}
+
+	reader := bufio.NewReader(input)
+	writer := bufio.NewWriter(output)
+	defer writer.Flush()
+
+	failures, rows := 0, 0
+	for line := 1; ; {
+		record, ending, lines, err := readCSVRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		recordLine := line
+		line += lines
+
+		fields := splitCSVRecord(record, delimiter)
+		if hasHeader && recordLine == 1 {
+			for _, column := range Columns {
+				if idx, err := strconv.Atoi(column); err == nil && idx > 0 {
+					continue
+				}
+				idx := slices.IndexFunc(fields, func(field csvField) bool {
+					return strings.TrimSpace(field.value) == column
+				})
+				if idx == -1 {
+					return fmt.Errorf("Column %q was not found in the header", column)
+				}
+				columns = append(columns, idx)
+			}
+			if err := writeCSVRow(writer, ending, fields, delimiter); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.TrimSpace(record) == "" {
+			if !JSONOutput {
+				writer.WriteString(record + ending)
+			}
+			continue
+		}
+
+		rows++
+		failed := false
+		for _, column := range columns {
+			if column >= len(fields) {
+				continue
+			}
+			field := &fields[column]
+			value := strings.TrimSpace(field.value)
+			if value == "" {
+				continue
+			}
+
+			converted, err := convert(value)
+			if err != nil {
+				switch OnError {
+				case "fail":
+					writer.Flush()
+					return fmt.Errorf("Line %d: %w", recordLine, err)
+				case "report":
+					printError(fmt.Errorf("Line %d: %w", recordLine, err))
+				}
+				failed = true
+				continue
+			}
+			field.replace(converted, delimiter)
+		}
+		if failed && OnError != "keep" {
+			if OnError == "report" {
+				failures++
+			}
+			continue
+		}
+
+		if err := writeCSVRow(writer, ending, fields, delimiter); err != nil {
+			return err
+		}
+	}
+
+	if failures > 0 {
+		writer.Flush()
+		return fmt.Errorf("Failed to parse %d of %d rows", failures, rows)
+	}
+	return nil
+}
+
+// Writes the fields of a row joined by the delimiter or with `--json` as an array of
+// their values on a line of its own
+func writeCSVRow(writer *bufio.Writer, ending string, fields []csvField, delimiter string) error {
+	if JSONOutput {
+		values := make([]string, len(fields))
+		for idx, field := range fields {
+			values[idx] = field.value
+		}
+		output, err := marshalOutput(values)
+		if err != nil {
+			return err
+		}
+		writer.WriteString(output + "\n")
+		return nil
+	}
+
+	for idx, field := range fields {
+		if idx > 0 {
+			writer.WriteString(delimiter)
+		}
+		writer.WriteString(field.raw)
+	}
+	writer.WriteString(ending)
+	return nil
+}
+
+// Reads the next record which spans several lines when a quoted field contains line
+// breaks, returning the record without its line ending and the number of lines read
+func readCSVRecord(reader *bufio.Reader) (string, string, int, error) {
+	var record strings.Builder
+	lines := 0
+	for {
+		text, err := reader.ReadString('\n')
+		if text == "" && err != nil {
+			if record.Len() > 0 {
+				return record.String(), "", lines, nil
+			}
+			return "", "", lines, err
+		}
+		record.WriteString(text)
+		lines++
+
+		// Escaped quotes come in pairs so an odd count leaves a quoted field open
+		if err == nil && strings.Count(record.String(), "\"")%2 == 1 {
+			continue
+		}
+
+		str := record.String()
+		switch {
+		case strings.HasSuffix(str, "\r\n"):
+			return str[:len(str)-2], "\r\n", lines, nil
+		case strings.HasSuffix(str, "\n"):
+			return str[:len(str)-1], "\n", lines, nil
+		}
+		return str, "", lines, nil
+	}
+}
+
+// Splits a record into its fields where delimiters within quotes are part of the field
+func splitCSVRecord(record string, delimiter string) []csvField {
+	fields := []csvField{}
+	start := 0
+	inQuotes := false
+	for idx := 0; idx < len(record); idx++ {
+		switch {
+		case record[idx] == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && strings.HasPrefix(record[idx:], delimiter):
+			fields = append(fields, newCSVField(record[start:idx]))
+			start = idx + len(delimiter)
+			idx = start - 1
+		}
+	}
+	return append(fields, newCSVField(record[start:]))
+}
+
+func newCSVField(raw string) csvField {
+	trimmed := strings.TrimSpace(raw)
+	if len(trimmed) >= 2 && strings.HasPrefix(trimmed, "\"") && strings.HasSuffix(trimmed, "\"") {
+		return csvField{
+			raw:    raw,
+			value:  strings.ReplaceAll(trimmed[1:len(trimmed)-1], "\"\"", "\""),
+			quoted: true,
+		}
+	}
+	return csvField{raw: raw, value: raw}
+}
+
+// Replaces the value of a field keeping the spacing around it e.g. ' 1746799240 '
+// becomes ' 2025-05-09 '
+func (field *csvField) replace(value string, delimiter string) {
+	start := len(field.raw) - len(strings.TrimLeftFunc(field.raw, unicode.IsSpace))
+	end := len(strings.TrimRightFunc(field.raw, unicode.IsSpace))
+	field.raw = field.raw[:start] + quoteCSVField(value, delimiter, field.quoted) + field.raw[end:]
+	field.value = value
+}
+
+// Quotes a converted value when the original was quoted or when the value contains the
+// delimiter, a quote or a line break
+func quoteCSVField(value string, delimiter string, quoted bool) string {
+	if quoted || strings.ContainsAny(value, delimiter+"\"\r\n") {
+		return "\"" + strings.ReplaceAll(value, "\"", "\"\"") + "\""
+	}
+	return value
+}
+
+// Reads `--delimiter` where 'tab' or '\t' separate columns with tabs
+func parseDelimiter() (rune, error) {
+	switch Delimiter {
+	case "tab", "\\t":
+		return '\t', nil
+	}
+
+	runes := []rune(Delimiter)
+	if len(runes) != 1 || strings.ContainsRune("\"\r\n", runes[0]) {
+		return 0, fmt.Errorf("Delimiter must be a single character other than a quote or line break: %q", Delimiter)
+	}
+	return runes[0], nil
+}
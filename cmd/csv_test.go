@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	scenarios := []struct {
+		stdin string
+		args  []string
+		want  string
+	}{
+		{
+			stdin: "id,when\n1, 1746799240 \n2,\"1746799240\"\n",
+			args:  []string{"csv", "-t", "UTC", "-c", "when", "-f", "rfc3339"},
+			want:  "id,when\n1, 2025-05-09T14:00:40Z \n2,\"2025-05-09T14:00:40Z\"\n",
+		},
+		{
+			stdin: "1\t 1746799240\r\n",
+			args:  []string{"csv", "-t", "UTC", "-c", "2", "-d", "tab", "-f", "rfc3339"},
+			want:  "1\t 2025-05-09T14:00:40Z\r\n",
+		},
+		{
+			stdin: "1;1746799240;x\n",
+			args:  []string{"csv", "-t", "UTC", "-c", "2", "-d", ";", "-f", "rfc3339"},
+			want:  "1;2025-05-09T14:00:40Z;x\n",
+		},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(strings.Join(testCase.args, " "), func(t *testing.T) {
+			output, err := runEra(t, testCase.stdin, testCase.args...)
+			if err != nil {
+				t.Fatalf("Failed to convert the CSV\n%s", err)
+			}
+			if output != testCase.want {
+				t.Errorf("Fail\nGot:  %q\nwant: %q", output, testCase.want)
+			}
+		})
+	}
+}
+
+func TestDelimiterInvalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"csv", "-c", "1", "-d", "\""},
+		{"csv", "-c", "1", "-d", ";;"},
+		{"duration", "-c", "1", "-d", "\""},
+		{"duration", "-c", "1", "-d", ""},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			if output, err := runEra(t, "1s\n", args...); err == nil {
+				t.Errorf("Expected '%s' to fail\nGot: %s", strings.Join(args, " "), output)
+			}
+		})
+	}
+}
+
+func TestCSVReport(t *testing.T) {
+	output, err := runEra(t, "id,when\n1,1746799240\n2,bad\n", "csv", "-t", "UTC", "-c", "when", "-f", "rfc", "--on-error", "report")
+	if want := "id,when\n1,2025-05-09T14:00:40Z\n"; output != want {
+		t.Errorf("Fail\nGot:  %q\nwant: %q", output, want)
+	}
+	if err == nil || err.Error() != "Failed to parse 1 of 2 rows" {
+		t.Errorf("Expected the count of failed rows as the error\nGot: %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"gitlab.com/monokuro/era/dateutils"
+	"gitlab.com/monokuro/era/localiser"
+
+	"github.com/go-playground/locales/en_GB"
+	"github.com/spf13/cobra"
+)
+
+// Output units to display a difference with or "calendar" for a breakdown
+var DiffOutput string
+
+func init() {
+	diffCmd.Flags().StringVarP(&Parser, "formatter", "F", "", "Formatter to interpret both of the supplied datetimes with")
+	diffCmd.Flags().StringVarP(&DiffOutput, "output", "o", "calendar", "Output units to display the difference as, 'calendar' for years, months, days and time, or json")
+	diffCmd.Flags().StringVarP(&Separator, "separator", "_", "", "Output units displayed with a visual separator with '_' by default (e.g. 1_000_000)")
+	diffCmd.Flags().BoolVarP(&Round, "int", "i", false, "Output difference as an integer rounded towards zero")
+	diffCmd.Flags().StringVarP(&TimeZone, "timezone", "t", "", "Time zone to count calendar days in")
+	diffCmd.Flags().StringVarP(&Locale, "locale", "l", "", "Locale to use in parsing")
+	sepFlag := diffCmd.Flags().Lookup("separator")
+	sepFlag.NoOptDefVal = "_"
+	rootCmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <from> <to> [layout]",
+	Short: "Difference between two times",
+	Long:  "Parse two times and output the difference from the first to the second, negative when the second time is earlier\n\nWithout a formatter or layout the format of each time is detected automatically",
+	Args:  cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		location := time.Now().Local().Location()
+		locale := en_GB.New()
+
+		if TimeZone != "" {
+			loc, err := time.LoadLocation(TimeZone)
+			if err != nil {
+				return err
+			}
+			location = loc
+		}
+
+		if Locale != "" {
+			parsedLocale, err := localiser.Parse(Locale)
+			if err != nil {
+				return err
+			}
+			locale = parsedLocale
+		}
+
+		layout := ""
+		if len(args) > 2 {
+			layout = args[2]
+		}
+
+		from, err := ParseTime(args[0], Parser, layout, locale, location)
+		if err != nil {
+			return err
+		}
+		to, err := ParseTime(args[1], Parser, layout, locale, location)
+		if err != nil {
+			return err
+		}
+
+		calendar := formatCalendarDuration(dateutils.CalendarDiff(from, to))
+		output := diffOutput{
+			From:        newTimeOutput(args[0], from, ""),
+			To:          newTimeOutput(args[1], to, ""),
+			Calendar:    calendar,
+			Nanoseconds: int64(to.Sub(from)),
+		}
+
+		if strings.ToLower(DiffOutput) == "calendar" {
+			if JSONOutput {
+				return printJSON(output)
+			}
+			fmt.Println(calendar)
+			return nil
+		}
+
+		outUnit, err := dateutils.DurationUnit(DiffOutput)
+		if err != nil {
+			return err
+		}
+
+		value := float64(to.Sub(from)) / float64(outUnit)
+		if JSONOutput {
+			if Round {
+				value = math.Trunc(value)
+			}
+			output.Unit = DiffOutput
+			output.Value = &value
+			return printJSON(output)
+		}
+		fmt.Println(formatNumber(value))
+		return nil
+	},
+}
+
+// Difference as output by `--json`
+type diffOutput struct {
+	From        timeOutput `json:"from"`
+	To          timeOutput `json:"to"`
+	Calendar    string     `json:"calendar"`
+	Nanoseconds int64      `json:"nanoseconds"`
+	// Difference in the units of `--output` when not a calendar breakdown
+	Unit  string   `json:"unit,omitempty"`
+	Value *float64 `json:"value,omitempty"`
+}
+
+// Formats a calendar duration as years, months and days followed by the time e.g.
+// "1 year 2 months 3 days 04:05:06"
+//
+// Zero years, months and days are omitted and fractional seconds are only shown
+// when present
+func formatCalendarDuration(dur dateutils.CalendarDuration) string {
+	var output strings.Builder
+
+	if dur.Years < 0 || dur.Months < 0 || dur.Days < 0 || dur.Duration < 0 {
+		output.WriteString("-")
+		dur = dur.Negate()
+	}
+
+	for _, part := range []struct {
+		value int
+		unit  string
+	}{
+		{dur.Years, "year"},
+		{dur.Months, "month"},
+		{dur.Days, "day"},
+	} {
+		if part.value == 0 {
+			continue
+		}
+		output.WriteString(fmt.Sprintf("%d %s", part.value, part.unit))
+		if part.value != 1 {
+			output.WriteString("s")
+		}
+		output.WriteString(" ")
+	}
+
+	hours := dur.Duration / time.Hour
+	minutes := dur.Duration % time.Hour / time.Minute
+	seconds := dur.Duration % time.Minute / time.Second
+	output.WriteString(fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds))
+	if nanos := dur.Duration % time.Second; nanos != 0 {
+		output.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", nanos), "0"))
+	}
+
+	return output.String()
+}
@@ -3,11 +3,19 @@ package cmd
 import (
 	"fmt"
 	"math"
+	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"gitlab.com/monokuro/era/dateutils"
+	"gitlab.com/monokuro/era/localiser"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en_GB"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var OutputDur string
@@ -15,9 +23,10 @@ var Separator string
 var Round bool
 
 func init() {
-	durationCmd.Flags().StringVarP(&OutputDur, "output", "o", "ms", "Output units to display the duration as")
+	durationCmd.Flags().StringVarP(&OutputDur, "output", "o", "ms", "Output units to display the duration as, a style: iso, human, compact, clock or go, or json")
 	durationCmd.Flags().StringVarP(&Separator, "separator", "_", "", "Output units displayed with a visual separator with '_' by default (e.g. 1_000_000)")
-	durationCmd.Flags().BoolVarP(&Round, "int", "i", false, "Output duration as an integer rounded down")
+	durationCmd.Flags().BoolVarP(&Round, "int", "i", false, "Output duration as an integer rounded towards zero")
+	durationCmd.Flags().StringVarP(&Locale, "locale", "l", "", "Locale to use for the human output style")
 	sepFlag := durationCmd.Flags().Lookup("separator")
 	sepFlag.NoOptDefVal = "_"
 	rootCmd.AddCommand(durationCmd)
@@ -27,129 +36,214 @@ var durationCmd = &cobra.Command{
 	Use:     "duration",
 	Aliases: []string{"dur"},
 	Short:   "Parse and convert durations",
-	Long:    "Parse and convert human readable durations into different units and formats",
+	Long:    "Parse and convert human readable or ISO 8601 durations into different units and formats\n\nDurations can be combined with +, -, *, / and % along with parentheses e.g. '(1h + 20m) % 15m'\n\nWithout a duration one is read from each line of stdin and summarised with the count, sum, mean, median, min, max and percentiles",
+	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		outUnit, err := durationUnit(OutputDur)
-		if err != nil {
-			return err
+		locale := en_GB.New()
+		if Locale != "" {
+			parsedLocale, err := localiser.Parse(Locale)
+			if err != nil {
+				return err
+			}
+			locale = parsedLocale
+		}
+
+		// Without a duration one is read from each line of stdin and summarised
+		if len(args) == 0 || args[0] == "-" {
+			// The usage isn't relevant to inputs that fail to parse
+			cmd.SilenceUsage = true
+			return durationStats(os.Stdin, locale)
 		}
 
-		res, err := parseDur(strings.TrimSpace(args[0]))
+		res, isDuration, err := dateutils.EvaluateDuration(strings.TrimSpace(args[0]))
 		if err != nil {
 			return err
 		}
 
-		unitRes := float64(res) / float64(outUnit)
-		if Round {
-			unitRes = math.Floor(unitRes)
+		if JSONOutput {
+			if !isDuration {
+				return printJSON(struct {
+					Input string  `json:"input"`
+					Value float64 `json:"value"`
+				}{args[0], res})
+			}
+			output, err := newDurationOutput(res, locale)
+			if err != nil {
+				return err
+			}
+			output.Input = args[0]
+			return printJSON(output)
 		}
 
-		if Separator != "" {
-			fmt.Println(formatFloatWithSeparator(unitRes, Separator))
-		} else {
-			fmt.Println(unitRes)
+		// Dividing durations by each other leaves a plain number without units
+		if !isDuration {
+			fmt.Println(formatNumber(res))
+			return nil
 		}
+
+		output, err := formatDuration(res, locale)
+		if err != nil {
+			return err
+		}
+		fmt.Println(output)
+
 		return nil
 	},
 }
 
-// Parses a provided duration string into a provided output following the `time`
-// package definition for time: 1 = 1 nanosecond
-func parseDur(durStr string) (float64, error) {
-	valStack := []rune{}
-	unitStack := []rune{}
+// Arguments starting with a sign followed by a digit, point, parenthesis or ISO 8601
+// 'P' e.g. '-1h30m' or '-(1h + 5m)'
+var signedDurationPattern = regexp.MustCompile(`^[-+][\d.(P]`)
 
-	parse := func(valStack, unitStack []rune) (float64, error) {
-		durVal, err := strconv.ParseFloat(string(valStack), 64)
-		if err != nil {
-			return 0, fmt.Errorf("Invalid duration value %q", string(valStack))
-		}
+// Moves durations starting with a sign after a '--' when running `era duration` so
+// that '-1h30m' is read as the duration rather than as shorthand flags
+func signedDurationArgs(args []string) []string {
+	if cmd, _, err := rootCmd.Find(args); err != nil || cmd != durationCmd {
+		return args
+	}
 
-		durUnit, err := durationUnit(string(unitStack))
-		if err != nil {
-			return 0, err
+	flags, signed := []string{}, []string{}
+	for idx, arg := range args {
+		if arg == "--" {
+			return args
 		}
-
-		return durVal * float64(durUnit), nil
+		if signedDurationPattern.MatchString(arg) && (idx == 0 || !durationValueFlag(args[idx-1])) {
+			signed = append(signed, arg)
+		} else {
+			flags = append(flags, arg)
+		}
+	}
+	if len(signed) == 0 {
+		return args
 	}
+	return append(append(flags, "--"), signed...)
+}
 
-	total := 0.
-	for _, char := range durStr {
-		switch {
-		case '0' <= char && char <= '9' || char == '.':
-			if len(unitStack) > 0 {
-				val, err := parse(valStack, unitStack)
-				if err != nil {
-					return 0, err
-				}
+// Whether the argument is a flag of `era duration` written without its value which
+// is then the next argument e.g. '--output' in '--output -1'
+func durationValueFlag(arg string) bool {
+	name, isLong := strings.CutPrefix(arg, "--")
+	var flag *pflag.Flag
+	switch {
+	case isLong && !strings.Contains(name, "="):
+		flag = durationCmd.Flags().Lookup(name)
+	case !isLong && len(arg) == 2 && arg[0] == '-':
+		flag = durationCmd.Flags().ShorthandLookup(arg[1:])
+	}
+	return flag != nil && flag.NoOptDefVal == "" && flag.Value.Type() != "bool"
+}
 
-				total += val
-				valStack = []rune{}
-				unitStack = []rune{}
-			}
+// Duration as output by `--json` in units and each output style
+type durationOutput struct {
+	Input        string  `json:"input,omitempty"`
+	Nanoseconds  float64 `json:"nanoseconds"`
+	Milliseconds float64 `json:"milliseconds"`
+	Seconds      float64 `json:"seconds"`
+	ISO          string  `json:"iso"`
+	Human        string  `json:"human"`
+	Compact      string  `json:"compact"`
+	Clock        string  `json:"clock"`
+	Go           string  `json:"go"`
+}
 
-			valStack = append(valStack, char)
-		case 'A' <= char && char <= 'Z':
-		case 'a' <= char && char <= 'z':
-			unitStack = append(unitStack, char)
-		case char == ' ' && len(valStack) > 0 && len(unitStack) == 0:
-			return 0, fmt.Errorf("Units are required for space separated durations")
-		case char == ' ' || char == '_':
-			continue
-		default:
-			return 0, fmt.Errorf("Invalid character: %q", char)
-		}
+func newDurationOutput(res float64, locale locales.Translator) (durationOutput, error) {
+	dur, err := exactDuration(res)
+	if err != nil {
+		return durationOutput{}, err
 	}
+	return durationOutput{
+		Nanoseconds:  res,
+		Milliseconds: res / float64(time.Millisecond),
+		Seconds:      res / float64(time.Second),
+		ISO:          formatISODuration(dur),
+		Human:        formatHumanDuration(dur, locale),
+		Compact:      formatCompactDuration(dur),
+		Clock:        formatClockDuration(dur),
+		Go:           dur.String(),
+	}, nil
+}
 
-	if len(valStack) > 0 || len(unitStack) > 0 {
-		val, err := parse(valStack, unitStack)
+// Formats a duration in nanoseconds following the `--output` flag as either a style
+// or a number of units
+func formatDuration(res float64, locale locales.Translator) (string, error) {
+	var style func(time.Duration) string
+	switch strings.ToLower(OutputDur) {
+	case "iso", "iso8601":
+		style = formatISODuration
+	case "human":
+		style = func(dur time.Duration) string { return formatHumanDuration(dur, locale) }
+	case "compact":
+		style = formatCompactDuration
+	case "clock":
+		style = formatClockDuration
+	case "go":
+		style = time.Duration.String
+	}
+	if style != nil {
+		dur, err := exactDuration(res)
 		if err != nil {
-			return 0, err
+			return "", err
 		}
+		return style(dur), nil
+	}
 
-		total += val
+	outUnit, err := dateutils.DurationUnit(OutputDur)
+	if err != nil {
+		return "", err
 	}
 
-	return total, nil
+	return formatNumber(res / float64(outUnit)), nil
+}
+
+// Converts a number of nanoseconds to a duration failing when it is too long to be
+// represented rather than overflowing
+//
+// As a float `math.MaxInt64` rounds up to 2^63 so equal values overflow too
+func exactDuration(res float64) (time.Duration, error) {
+	if math.Abs(res) >= math.MaxInt64 {
+		return 0, fmt.Errorf("Duration is longer than the maximum of %s, output it in units such as --output h", time.Duration(math.MaxInt64))
+	}
+	return time.Duration(res), nil
 }
 
-func durationUnit(durUnit string) (int, error) {
-	switch durUnit {
-	case "d", "day", "days":
-		return int(time.Hour * 24), nil
-	case "h", "hour", "hours":
-		return int(time.Hour), nil
-	case "m", "minute", "minutes":
-		return int(time.Minute), nil
-	case "s", "second", "seconds":
-		return int(time.Second), nil
-	case "ms", "millisecond", "milliseconds":
-		return int(time.Millisecond), nil
-	case "ns", "nanosecond", "nanoseconds":
-		return int(time.Nanosecond), nil
-	case "":
-		return 0, fmt.Errorf("Units are required")
+// Formats a number following the `--int` and `--separator` flags
+func formatNumber(val float64) string {
+	if Round {
+		val = math.Trunc(val)
 	}
 
-	return 0, fmt.Errorf("Invalid unit: %q", durUnit)
+	if Separator != "" {
+		return formatFloatWithSeparator(val, Separator)
+	}
+	return fmt.Sprint(val)
 }
 
 // Formats an int value into one with character separators for visual clarity
 func formatIntWithSeparator(val int, separator string) string {
 	numStr := strconv.Itoa(val)
+	sign := ""
+	if val < 0 {
+		sign, numStr = "-", numStr[1:]
+	}
+
 	output := numStr
 	for idx := len(numStr) - 1; idx > 0; idx -= 1 {
-		if (idx+1)%3 == 0 {
+		if (len(numStr)-idx)%3 == 0 {
 			output = output[:idx] + separator + output[idx:]
 		}
 	}
 
-	return output
+	return sign + output
 }
 
-// Formats an int value into one with character separators for visual clarity
+// Formats a float value into one with character separators for visual clarity
 func formatFloatWithSeparator(val float64, separator string) string {
 	numStr := strconv.FormatFloat(val, 'f', -1, 64)
+	sign := ""
+	if strings.HasPrefix(numStr, "-") {
+		sign, numStr = "-", numStr[1:]
+	}
+
 	output := numStr
 	pointOffset := strings.IndexRune(numStr, '.')
 	if pointOffset == -1 {
@@ -161,5 +255,118 @@ func formatFloatWithSeparator(val float64, separator string) string {
 		}
 	}
 
-	return output
+	return sign + output
+}
+
+// Formats a duration as an ISO 8601 duration e.g. "P1DT2H30M" where days are 24 hours
+func formatISODuration(dur time.Duration) string {
+	var output strings.Builder
+
+	if dur < 0 {
+		output.WriteString("-")
+		dur = -dur
+	}
+	output.WriteString("P")
+
+	if days := dur / (24 * time.Hour); days > 0 {
+		output.WriteString(fmt.Sprintf("%dD", days))
+		dur -= days * 24 * time.Hour
+	}
+	if dur == 0 {
+		if output.Len() <= 2 {
+			output.WriteString("T0S")
+		}
+		return output.String()
+	}
+
+	output.WriteString("T")
+	if hours := dur / time.Hour; hours > 0 {
+		output.WriteString(fmt.Sprintf("%dH", hours))
+	}
+	if minutes := dur % time.Hour / time.Minute; minutes > 0 {
+		output.WriteString(fmt.Sprintf("%dM", minutes))
+	}
+	if seconds := dur % time.Minute; seconds > 0 {
+		output.WriteString(strconv.FormatFloat(seconds.Seconds(), 'f', -1, 64) + "S")
+	}
+
+	return output.String()
+}
+
+// Units shown by the human and compact output styles from largest to smallest
+var durationParts = []struct {
+	name  string
+	short string
+	unit  time.Duration
+}{
+	{"day", "d", 24 * time.Hour},
+	{"hour", "h", time.Hour},
+	{"minute", "m", time.Minute},
+	{"second", "s", time.Second},
+	{"millisecond", "ms", time.Millisecond},
+	{"microsecond", "µs", time.Microsecond},
+	{"nanosecond", "ns", time.Nanosecond},
+}
+
+// Formats a duration as each non-zero unit in words e.g. "1 hour 5 minutes" using the
+// language and plural rules of the locale
+func formatHumanDuration(dur time.Duration, locale locales.Translator) string {
+	sign := ""
+	if dur < 0 {
+		sign, dur = "-", -dur
+	}
+
+	parts := []string{}
+	for _, part := range durationParts {
+		if count := dur / part.unit; count > 0 {
+			parts = append(parts, localiser.FormatUnit(locale, part.name, float64(count), 0))
+			dur -= count * part.unit
+		}
+	}
+	if len(parts) == 0 {
+		return localiser.FormatUnit(locale, "second", 0, 0)
+	}
+
+	return sign + strings.Join(parts, " ")
+}
+
+// Formats a duration as each non-zero unit abbreviated e.g. "1d2h5m" which can be
+// parsed by `era duration`
+func formatCompactDuration(dur time.Duration) string {
+	var output strings.Builder
+
+	if dur < 0 {
+		output.WriteString("-")
+		dur = -dur
+	}
+	if dur == 0 {
+		return "0s"
+	}
+
+	for _, part := range durationParts {
+		if count := dur / part.unit; count > 0 {
+			output.WriteString(fmt.Sprintf("%d%s", count, part.short))
+			dur -= count * part.unit
+		}
+	}
+
+	return output.String()
+}
+
+// Formats a duration as hours, minutes, seconds and milliseconds e.g. "01:05:00.000"
+// where the hours can exceed 24
+func formatClockDuration(dur time.Duration) string {
+	sign := ""
+	if dur < 0 {
+		sign, dur = "-", -dur
+	}
+
+	return fmt.Sprintf(
+		"%s%02d:%02d:%02d.%03d",
+		sign,
+		dur/time.Hour,
+		dur%time.Hour/time.Minute,
+		dur%time.Minute/time.Second,
+		dur%time.Second/time.Millisecond,
+	)
 }
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/locales/en_GB"
+	"github.com/go-playground/locales/fr_FR"
+)
+
+func TestFormatDurationStyles(t *testing.T) {
+	scenarios := []struct {
+		dur     time.Duration
+		human   string
+		compact string
+		clock   string
+	}{
+		{dur: 3900250 * time.Millisecond, human: "1 hour 5 minutes 250 milliseconds", compact: "1h5m250ms", clock: "01:05:00.250"},
+		{dur: 2 * time.Hour, human: "2 hours", compact: "2h", clock: "02:00:00.000"},
+		{dur: 26*time.Hour + time.Second, human: "1 day 2 hours 1 second", compact: "1d2h1s", clock: "26:00:01.000"},
+		{dur: -90 * time.Second, human: "-1 minute 30 seconds", compact: "-1m30s", clock: "-00:01:30.000"},
+		{dur: 1500 * time.Nanosecond, human: "1 microsecond 500 nanoseconds", compact: "1µs500ns", clock: "00:00:00.000"},
+		{dur: 0, human: "0 seconds", compact: "0s", clock: "00:00:00.000"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.dur.String(), func(t *testing.T) {
+			t.Parallel()
+			if got := formatHumanDuration(testCase.dur, en_GB.New()); got != testCase.human {
+				t.Errorf("Fail formatting %s as human\nGot:  %s\nwant: %s", testCase.dur, got, testCase.human)
+			}
+			if got := formatCompactDuration(testCase.dur); got != testCase.compact {
+				t.Errorf("Fail formatting %s as compact\nGot:  %s\nwant: %s", testCase.dur, got, testCase.compact)
+			}
+			if got := formatClockDuration(testCase.dur); got != testCase.clock {
+				t.Errorf("Fail formatting %s as clock\nGot:  %s\nwant: %s", testCase.dur, got, testCase.clock)
+			}
+		})
+	}
+}
+
+func TestFormatHumanDurationLocale(t *testing.T) {
+	scenarios := []struct {
+		dur  time.Duration
+		want string
+	}{
+		{dur: 3900250 * time.Millisecond, want: "1 heure 5 minutes 250 millisecondes"},
+		{dur: 2 * time.Hour, want: "2 heures"},
+		{dur: time.Second, want: "1 seconde"},
+		{dur: 0, want: "0 seconde"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.dur.String(), func(t *testing.T) {
+			t.Parallel()
+			if got := formatHumanDuration(testCase.dur, fr_FR.New()); got != testCase.want {
+				t.Errorf("Fail formatting %s in French\nGot:  %s\nwant: %s", testCase.dur, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestFormatDurationTooLong(t *testing.T) {
+	for _, style := range []string{"iso", "human", "compact", "clock", "go"} {
+		for _, res := range []float64{3e6 * float64(time.Hour), -3e6 * float64(time.Hour)} {
+			OutputDur = style
+			if got, err := formatDuration(res, en_GB.New()); err == nil {
+				t.Errorf("Expected %v nanoseconds to be too long to format as %s\nGot: %s", res, style, got)
+			}
+		}
+	}
+	OutputDur = "ms"
+}
+
+func TestSignedDurationArgs(t *testing.T) {
+	scenarios := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"duration", "-1h30m", "-o", "m"}, want: "-90\n"},
+		{args: []string{"duration", "-o", "m", "-1h30m"}, want: "-90\n"},
+		{args: []string{"dur", "--output", "m", "-PT1H"}, want: "-60\n"},
+		{args: []string{"duration", "-o", "m", "-(1h + 5m)"}, want: "-65\n"},
+		{args: []string{"duration", "-i", "-1.5h", "-o", "h"}, want: "-1\n"},
+		{args: []string{"duration", "-o", "m", "--", "-1h30m"}, want: "-90\n"},
+		{args: []string{"--json", "duration", "+2h", "-o", "h"}, want: "{\"input\":\"+2h\""},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(strings.Join(testCase.args, " "), func(t *testing.T) {
+			output, err := runEra(t, "", testCase.args...)
+			if err != nil {
+				t.Fatalf("Failed to run '%s'\n%s", strings.Join(testCase.args, " "), err)
+			}
+			if !strings.HasPrefix(output, testCase.want) {
+				t.Errorf("Fail\nGot:  %q\nwant: %q", output, testCase.want)
+			}
+		})
+	}
+}
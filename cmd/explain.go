@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"gitlab.com/monokuro/era/localiser"
+	"gitlab.com/monokuro/era/parser"
+
+	"github.com/fatih/color"
+	"github.com/go-playground/locales/en_GB"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	explainCmd.Flags().StringVarP(&Parser, "formatter", "F", "", "Formatter the layout is written for")
+	explainCmd.Flags().StringVarP(&TimeZone, "timezone", "t", "", "Time zone of the sample time")
+	explainCmd.Flags().StringVarP(&Locale, "locale", "l", "", "Locale to use in the sample")
+	explainCmd.Flags().StringVarP(&At, "at", "a", "", "Natural language time to use as the sample instead of now e.g. 'tomorrow 9am'")
+	rootCmd.AddCommand(explainCmd)
+}
+
+// Section of a layout along with its expansion for the sample time
+type explainedSection struct {
+	parser.LayoutSection
+	Sample string `json:"sample"`
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <layout>",
+	Short: "Describe each token of a layout",
+	Long:  "Break a layout down into its tokens, literal text and escaped sections as read by a formatter, describing each token along with what it outputs for the current or a sample time",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if Parser == "" {
+			return fmt.Errorf("No formatter specified")
+		}
+
+		dt := time.Now()
+		locale := en_GB.New()
+
+		if TimeZone != "" {
+			location, err := time.LoadLocation(TimeZone)
+			if err != nil {
+				return err
+			}
+			dt = dt.In(location)
+		}
+
+		if At != "" {
+			parsed, err := parser.ParseNatural(At, dt)
+			if err != nil {
+				return fmt.Errorf("Failed to parse %q as a natural language date: %w", At, err)
+			}
+			dt = parsed
+		}
+
+		if Locale != "" {
+			parsedLocale, err := localiser.Parse(Locale)
+			if err != nil {
+				return err
+			}
+			locale = parsedLocale
+		}
+
+		layoutSections, err := parser.LayoutSections(args[0], Parser)
+		if err != nil {
+			return err
+		}
+
+		sections := make([]explainedSection, len(layoutSections))
+		for idx, section := range layoutSections {
+			sample := section.Literal
+			switch {
+			case section.Token:
+				sample, err = FormatTime(dt, locale, Parser, section.Raw)
+				if err != nil {
+					return err
+				}
+			case section.Unknown:
+				sample = section.Raw
+			}
+			sections[idx] = explainedSection{section, sample}
+		}
+
+		if JSONOutput {
+			return printJSON(sections)
+		}
+
+		fullSample, err := FormatTime(dt, locale, Parser, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s -> %s\n\n", args[0], fullSample)
+
+		rawWidth, sampleWidth := 0, 0
+		for _, section := range sections {
+			rawWidth = max(rawWidth, len(fmt.Sprintf("%q", section.Raw)))
+			sampleWidth = max(sampleWidth, len(fmt.Sprintf("%q", section.Sample)))
+		}
+
+		for _, section := range sections {
+			raw := fmt.Sprintf("%-*q", rawWidth, section.Raw)
+			sample := fmt.Sprintf("%-*q", sampleWidth, section.Sample)
+			desc := section.Desc
+			colour := color.New()
+			switch {
+			case section.Token:
+				colour = color.New(color.FgCyan)
+			case section.Unknown:
+				colour = color.New(color.FgRed)
+				desc = "Unknown token output as is"
+			case section.Escaped:
+				colour = color.New(color.FgMagenta)
+				desc = "Escaped literal text"
+			case desc != "":
+				// Sections such as the brackets around optional sections describe themselves
+				colour = color.New(color.FgYellow)
+			default:
+				desc = "Literal text"
+			}
+			if !NoColor {
+				raw = colour.Sprint(raw)
+				sample = color.GreenString("%s", sample)
+			}
+			fmt.Println(strings.TrimRight(fmt.Sprintf("%s  %s  %s", raw, sample, desc), " "))
+		}
+
+		return nil
+	},
+}
@@ -18,7 +18,11 @@ var formatterCmd = &cobra.Command{
 	Use:   "formatter",
 	Short: "List all available formatters",
 	Long:  "List all formatters available to use with any command that supports a formatter argument",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if JSONOutput {
+			return printJSON(nameAliasList(formatterMap, func(desc formatterDesc) []string { return desc.alias }))
+		}
+
 		var output strings.Builder
 
 		for name, meta := range formatterMap {
@@ -33,6 +37,7 @@ var formatterCmd = &cobra.Command{
 		}
 
 		fmt.Print(output.String())
+		return nil
 	},
 }
 
@@ -51,14 +56,20 @@ var formatterMap = map[string]formatterDesc{
 	"iso": {
 		alias: []string{"iso8601"},
 	},
-	"go": {formatter: &parser.Go},
+	"go":       {formatter: &parser.Go},
+	"relative": {},
 	"moment": {
 		formatter: &parser.MomentJs,
 		alias:     []string{"momentjs"},
 	},
 	"luxon": {formatter: &parser.Luxon},
+	"java":  {formatter: &parser.Java},
+	"dotnet": {
+		formatter: &parser.DotNet,
+		alias:     []string{".net", "csharp"},
+	},
 	"strftime": {
-		formatter: &parser.CStr,
+		formatter: strftimeHandler,
 		alias:     []string{"c", "strptime"},
 	},
 	"go:strftime": {
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	_ "time/tzdata"
+
+	"gitlab.com/monokuro/era/dateutils"
+	"gitlab.com/monokuro/era/localiser"
+
+	"github.com/fatih/color"
+	"github.com/go-playground/locales/en_GB"
+	"github.com/spf13/cobra"
+)
+
+// Additional time zones to show an instant in
+var Zones []string
+
+func init() {
+	infoCmd.Flags().StringVarP(&Parser, "formatter", "F", "", "Formatter to interpret the supplied datetime with")
+	infoCmd.Flags().StringVarP(&TimeZone, "timezone", "t", "", "Time zone to describe the time in")
+	infoCmd.Flags().StringVarP(&Locale, "locale", "l", "", "Locale to use in parsing and for the weekday")
+	infoCmd.Flags().StringSliceVarP(&Zones, "zones", "z", nil, "Other time zones to show the instant in, repeat or separate with commas e.g. 'UTC,America/New_York'")
+	rootCmd.AddCommand(infoCmd)
+}
+
+// Properties of an instant shown by `era info`
+type infoOutput struct {
+	Unix      int64  `json:"unix"`
+	UnixMilli int64  `json:"unix_milli"`
+	UnixNano  int64  `json:"unix_nano"`
+	UTC       string `json:"utc"`
+	// RFC 3339 time in the zone of `--timezone`, by default the local zone
+	Time        string `json:"time"`
+	ISOWeek     int    `json:"iso_week"`
+	ISOWeekYear int    `json:"iso_week_year"`
+	DayOfYear   int    `json:"day_of_year"`
+	Quarter     int    `json:"quarter"`
+	Weekday     string `json:"weekday"`
+	DST         bool   `json:"dst"`
+	Zone        string `json:"zone"`
+	// Abbreviated name of the zone at the time e.g. 'BST'
+	Abbreviation string `json:"abbreviation"`
+	// Offset from UTC in seconds
+	Offset      int     `json:"offset"`
+	LeapYear    bool    `json:"leap_year"`
+	DaysInMonth int     `json:"days_in_month"`
+	JulianDay   float64 `json:"julian_day"`
+	// RFC 3339 time in each zone of `--zones`
+	Zones map[string]string `json:"zones,omitempty"`
+}
+
+var infoCmd = &cobra.Command{
+	Use:   "info [time] [layout]",
+	Short: "Describe every property of a time",
+	Long:  "Show the unix timestamps, ISO week, day of year, quarter, weekday, time zone, daylight saving, leap year, days in the month and Julian day of the current or supplied time along with the same instant in other time zones",
+	Args:  cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		location := time.Now().Local().Location()
+		locale := en_GB.New()
+
+		if TimeZone != "" {
+			loc, err := time.LoadLocation(TimeZone)
+			if err != nil {
+				return err
+			}
+			location = loc
+		}
+
+		if Locale != "" {
+			parsedLocale, err := localiser.Parse(Locale)
+			if err != nil {
+				return err
+			}
+			locale = parsedLocale
+		}
+
+		dt := time.Now().In(location)
+		if len(args) > 0 {
+			layout := ""
+			if len(args) > 1 {
+				layout = args[1]
+			}
+
+			parsed, err := ParseTime(args[0], Parser, layout, locale, location)
+			if err != nil {
+				return err
+			}
+			dt = parsed.In(location)
+		}
+
+		isoYear, isoWeek := dt.ISOWeek()
+		abbreviation, offset := dt.Zone()
+		info := infoOutput{
+			Unix:         dt.Unix(),
+			UnixMilli:    dt.UnixMilli(),
+			UnixNano:     dt.UnixNano(),
+			UTC:          dt.UTC().Format(time.RFC3339Nano),
+			Time:         dt.Format(time.RFC3339Nano),
+			ISOWeek:      isoWeek,
+			ISOWeekYear:  isoYear,
+			DayOfYear:    dt.YearDay(),
+			Quarter:      dateutils.YearQuarter(dt),
+			Weekday:      locale.WeekdayWide(dt.Weekday()),
+			DST:          dt.IsDST(),
+			Zone:         location.String(),
+			Abbreviation: abbreviation,
+			Offset:       offset,
+			LeapYear:     dateutils.IsLeapYear(dt.Year()),
+			DaysInMonth:  dateutils.DaysInMonth(dt),
+			JulianDay:    dateutils.JulianDay(dt),
+		}
+
+		for _, zone := range Zones {
+			loc, err := time.LoadLocation(zone)
+			if err != nil {
+				return err
+			}
+			if info.Zones == nil {
+				info.Zones = map[string]string{}
+			}
+			info.Zones[zone] = dt.In(loc).Format(time.RFC3339Nano)
+		}
+
+		if JSONOutput {
+			return printJSON(info)
+		}
+
+		rows := [][2]string{
+			{"unix", strconv.FormatInt(info.Unix, 10)},
+			{"unix ms", strconv.FormatInt(info.UnixMilli, 10)},
+			{"unix ns", strconv.FormatInt(info.UnixNano, 10)},
+			{"utc", info.UTC},
+			{"time", info.Time},
+			{"iso week", fmt.Sprintf("%d-W%02d-%d", isoYear, isoWeek, (int(dt.Weekday())+6)%7+1)},
+			{"day of year", strconv.Itoa(info.DayOfYear)},
+			{"quarter", fmt.Sprintf("Q%d", info.Quarter)},
+			{"weekday", info.Weekday},
+			{"zone", fmt.Sprintf("%s (%s, %s)", info.Zone, abbreviation, dt.Format("-07:00"))},
+			{"dst", yesNo(info.DST)},
+			{"leap year", yesNo(info.LeapYear)},
+			{"days in month", strconv.Itoa(info.DaysInMonth)},
+			{"julian day", strconv.FormatFloat(info.JulianDay, 'f', 6, 64)},
+		}
+		for _, zone := range Zones {
+			rows = append(rows, [2]string{zone, info.Zones[zone]})
+		}
+
+		keyWidth := 0
+		for _, row := range rows {
+			keyWidth = max(keyWidth, len(row[0]))
+		}
+		for _, row := range rows {
+			key := fmt.Sprintf("%-*s", keyWidth, row[0])
+			if !NoColor {
+				key = color.CyanString("%s", key)
+			}
+			fmt.Printf("%s  %s\n", key, row[1])
+		}
+
+		return nil
+	},
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestInfo(t *testing.T) {
+	output, err := runEra(t, "", "info", "-t", "Europe/London", "--zones", "UTC,America/New_York", "2025-05-09T14:00:40Z")
+	if err != nil {
+		t.Fatalf("Failed to describe the time\n%s", err)
+	}
+	want := `unix              1746799240
+unix ms           1746799240000
+unix ns           1746799240000000000
+utc               2025-05-09T14:00:40Z
+time              2025-05-09T15:00:40+01:00
+iso week          2025-W19-5
+day of year       129
+quarter           Q2
+weekday           Friday
+zone              Europe/London (BST, +01:00)
+dst               yes
+leap year         no
+days in month     31
+julian day        2460805.083796
+UTC               2025-05-09T14:00:40Z
+America/New_York  2025-05-09T10:00:40-04:00
+`
+	if output != want {
+		t.Errorf("Fail\nGot:  %q\nwant: %q", output, want)
+	}
+}
+
+func TestInfoJSON(t *testing.T) {
+	output, err := runEra(t, "", "info", "--json", "-t", "Asia/Kolkata", "-l", "fr", "2024-02-29T20:00:00Z")
+	if err != nil {
+		t.Fatalf("Failed to describe the time\n%s", err)
+	}
+	var got infoOutput
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("Expected a JSON object\nGot: %s\n%s", output, err)
+	}
+	want := infoOutput{
+		Unix:         1709236800,
+		UnixMilli:    1709236800000,
+		UnixNano:     1709236800000000000,
+		UTC:          "2024-02-29T20:00:00Z",
+		Time:         "2024-03-01T01:30:00+05:30",
+		ISOWeek:      9,
+		ISOWeekYear:  2024,
+		DayOfYear:    61,
+		Quarter:      1,
+		Weekday:      "vendredi",
+		Zone:         "Asia/Kolkata",
+		Abbreviation: "IST",
+		Offset:       19800,
+		LeapYear:     true,
+		DaysInMonth:  31,
+		JulianDay:    2460370.3333333335,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fail\nGot:  %+v\nwant: %+v", got, want)
+	}
+}
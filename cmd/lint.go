@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"gitlab.com/monokuro/era/parser"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Whether a layout being linted is also used for parsing
+var ForParsing bool
+
+func init() {
+	lintCmd.Flags().StringVarP(&Parser, "formatter", "F", "", "Formatter the layout is written for")
+	lintCmd.Flags().BoolVarP(&ForParsing, "parse", "p", false, "Treat tokens that can only be used for formatting as problems")
+	rootCmd.AddCommand(lintCmd)
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint <layout>",
+	Short: "Check a layout for problems",
+	Long:  "Check a layout for unknown tokens, unterminated escapes, unescaped letters and ambiguous runs of a letter that formatters silently output as is or read differently than intended, exiting with a non-zero status when any are found\n\nTokens that can only be used for formatting are noted and only treated as problems with --parse",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if Parser == "" {
+			return fmt.Errorf("No formatter specified")
+		}
+
+		problems, err := parser.LintLayout(args[0], Parser)
+		if err != nil {
+			return err
+		}
+
+		failures := 0
+		for _, problem := range problems {
+			if !problem.ParseOnly || ForParsing {
+				failures++
+			}
+		}
+
+		if JSONOutput {
+			if err := printJSON(struct {
+				Layout   string                 `json:"layout"`
+				Problems []parser.LayoutProblem `json:"problems"`
+			}{args[0], problems}); err != nil {
+				return err
+			}
+		} else {
+			for _, problem := range problems {
+				label := "problem:"
+				colour := color.New(color.FgRed)
+				if problem.ParseOnly && !ForParsing {
+					label = "note:"
+					colour = color.New(color.FgYellow)
+				}
+				if !NoColor {
+					label = colour.Sprint(label)
+				}
+				fmt.Printf("%d: %s %s\n", problem.Offset, label, problem.Message)
+			}
+		}
+
+		if failures > 0 {
+			// The usage isn't relevant to problems with the layout itself
+			cmd.SilenceUsage = true
+			if failures == 1 {
+				return fmt.Errorf("Found 1 problem in %q", args[0])
+			}
+			return fmt.Errorf("Found %d problems in %q", failures, args[0])
+		}
+		return nil
+	},
+}
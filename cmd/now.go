@@ -25,10 +25,20 @@ var TimeZone string
 // Locale string to use when formatting date times
 var Locale string
 
+// Natural language expression relative to the current time to output instead
+var At string
+
+// Date time the relative formatter describes times from instead of the current time
+var Reference string
+
 func init() {
 	nowCmd.Flags().StringVarP(&Format, "formatter", "F", "", "Formatter to interpret and display the current datetime with")
 	nowCmd.Flags().StringVarP(&TimeZone, "timezone", "t", "", "Time zone to set the time to")
 	nowCmd.Flags().StringVarP(&Locale, "locale", "l", "", "Locale to use in formatting")
+	nowCmd.Flags().StringVarP(&At, "at", "a", "", "Natural language time relative to now e.g. 'tomorrow 9am' or '3 days ago'")
+	nowCmd.Flags().StringVar(&Reference, "reference", "", "Date time the relative formatter describes times from e.g. '2025-05-09 12:00', by default now")
+	addShiftFlags(nowCmd)
+	addRoundFlags(nowCmd)
 	rootCmd.AddCommand(nowCmd)
 }
 
@@ -48,6 +58,14 @@ var nowCmd = &cobra.Command{
 			now = now.In(location)
 		}
 
+		if At != "" {
+			dt, err := parser.ParseNatural(At, now)
+			if err != nil {
+				return fmt.Errorf("Failed to parse %q as a natural language date: %w", At, err)
+			}
+			now = dt
+		}
+
 		if Locale != "" {
 			parsedLocale, err := localiser.Parse(Locale)
 			if err != nil {
@@ -61,10 +79,23 @@ var nowCmd = &cobra.Command{
 			parseStr = args[0]
 		}
 
+		now, err := shiftTime(now)
+		if err != nil {
+			return err
+		}
+
+		now, err = roundTime(now)
+		if err != nil {
+			return err
+		}
+
 		nowFormatted, err := FormatTime(now, locale, Format, parseStr)
 		if err != nil {
 			return err
 		}
+		if JSONOutput {
+			return printJSON(newTimeOutput("", now, nowFormatted))
+		}
 		fmt.Println(nowFormatted)
 
 		return nil
@@ -96,13 +127,42 @@ func FormatTime(dt time.Time, locale locales.Translator, formatter string, parse
 			return formattedTime, fmt.Errorf("No format string provided")
 		}
 		formattedTime = parser.Luxon.Format(dt, locale, &parseStr)
+	case "java":
+		if len(parseStr) == 0 {
+			return formattedTime, fmt.Errorf("No format string provided")
+		}
+		if err := parser.Java.ValidateLayout(parseStr); err != nil {
+			return formattedTime, err
+		}
+		formattedTime = parser.Java.Format(dt, locale, &parseStr)
+	case "dotnet", ".net", "csharp":
+		if len(parseStr) == 0 {
+			return formattedTime, fmt.Errorf("No format string provided")
+		}
+		if err := parser.DotNet.ValidateLayout(parseStr); err != nil {
+			return formattedTime, err
+		}
+		formattedTime = parser.DotNet.Format(dt, locale, &parseStr)
 	case "go:strftime", "go:strptime":
 		if len(parseStr) == 0 {
 			return formattedTime, fmt.Errorf("No format string provided")
 		}
 		formattedTime = parser.GoStrptime.Format(dt, locale, &parseStr)
 	case "c", "strftime", "strptime":
-		formattedTime = parser.CStr.Format(dt, locale, &parseStr)
+		formattedTime = strftimeHandler.Format(dt, locale, &parseStr)
+	case "relative":
+		reference, err := referenceTime(locale, dt.Location())
+		if err != nil {
+			return formattedTime, err
+		}
+		switch strings.ToLower(parseStr) {
+		case "":
+			formattedTime = parser.FormatRelative(dt, reference, locale)
+		case "calendar":
+			formattedTime = parser.FormatCalendar(dt, reference, locale)
+		default:
+			return formattedTime, fmt.Errorf("%q is not a relative style, use 'calendar' or no style", parseStr)
+		}
 	case "":
 		formattedTime = dt.String()
 	default:
@@ -111,3 +171,17 @@ func FormatTime(dt time.Time, locale locales.Translator, formatter string, parse
 
 	return formattedTime, nil
 }
+
+// Date time the relative formatter describes times from following `--reference` where
+// the format is detected, including natural language e.g. 'yesterday noon' in the
+// location of the date time
+func referenceTime(locale locales.Translator, location *time.Location) (time.Time, error) {
+	if Reference == "" {
+		return time.Now(), nil
+	}
+	dt, _, err := parser.Detect(Reference, locale, location)
+	if err != nil {
+		return dt, fmt.Errorf("Unable to parse the reference %q: %w", Reference, err)
+	}
+	return dt, nil
+}
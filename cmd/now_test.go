@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRelativeReference(t *testing.T) {
+	scenarios := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"parse", "-t", "UTC", "-f", "relative", "--reference", "2025-05-12T09:00:00Z", "2025-05-09T15:00:00Z"}, want: "3 days ago\n"},
+		{args: []string{"parse", "-t", "UTC", "-f", "relative", "--reference", "1746799240", "2025-05-09T15:00:40Z"}, want: "in an hour\n"},
+		{args: []string{"now", "-t", "UTC", "--at", "2025-05-08 14:00", "-F", "relative", "--reference", "2025-05-09T12:00:00Z", "calendar"}, want: "yesterday at 14:00\n"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(strings.Join(testCase.args, " "), func(t *testing.T) {
+			output, err := runEra(t, "", testCase.args...)
+			if err != nil {
+				t.Fatalf("Failed to run '%s'\n%s", strings.Join(testCase.args, " "), err)
+			}
+			if output != testCase.want {
+				t.Errorf("Fail\nGot:  %q\nwant: %q", output, testCase.want)
+			}
+		})
+	}
+
+	if output, err := runEra(t, "", "parse", "-f", "relative", "--reference", "nonsense", "1746799240"); err == nil {
+		t.Errorf("Expected an invalid reference to fail\nGot: %s", output)
+	}
+	if output, err := runEra(t, "", "duration", "--reference", "1746799240", "1h"); err == nil {
+		t.Errorf("Expected --reference to be unknown to duration\nGot: %s", output)
+	}
+}
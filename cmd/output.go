@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"time"
+)
+
+// Date time as output by `--json`
+type timeOutput struct {
+	Input     string `json:"input,omitempty"`
+	Formatted string `json:"formatted,omitempty"`
+	RFC3339   string `json:"rfc3339,omitempty"`
+	Unix      int64  `json:"unix"`
+	UnixNano  int64  `json:"unix_nano"`
+	Zone      string `json:"zone,omitempty"`
+	// Abbreviated name of the zone at the time e.g. 'BST'
+	Abbreviation string `json:"abbreviation,omitempty"`
+	// Offset from UTC in seconds
+	Offset int    `json:"offset"`
+	Error  string `json:"error,omitempty"`
+}
+
+func newTimeOutput(input string, dt time.Time, formatted string) timeOutput {
+	abbreviation, offset := dt.Zone()
+	return timeOutput{
+		Input:        input,
+		Formatted:    formatted,
+		RFC3339:      dt.Format(time.RFC3339Nano),
+		Unix:         dt.Unix(),
+		UnixNano:     dt.UnixNano(),
+		Zone:         dt.Location().String(),
+		Abbreviation: abbreviation,
+		Offset:       offset,
+	}
+}
+
+// Encodes a value as a single line of JSON so streams of objects can be read line by
+// line
+func marshalOutput(value any) (string, error) {
+	output, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("Unable to encode the output as JSON: %w", err)
+	}
+	return string(output), nil
+}
+
+// Prints a value as a single line of JSON
+func printJSON(value any) error {
+	output, err := marshalOutput(value)
+	if err != nil {
+		return err
+	}
+	fmt.Println(output)
+	return nil
+}
+
+// Prints an error on stderr, as a JSON object with an 'error' key under `--json`
+func printError(err error) {
+	if JSONOutput {
+		output, marshalErr := marshalOutput(struct {
+			Error string `json:"error"`
+		}{err.Error()})
+		if marshalErr == nil {
+			fmt.Fprintln(os.Stderr, output)
+			return
+		}
+	}
+	fmt.Fprintln(os.Stderr, err)
+}
+
+// Formatter or parser as output by `--json`
+type nameAliases struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// Lists the names and aliases of formatters or parsers sorted by name
+func nameAliasList[T any](descs map[string]T, aliases func(desc T) []string) []nameAliases {
+	list := []nameAliases{}
+	for _, name := range slices.Sorted(maps.Keys(descs)) {
+		list = append(list, nameAliases{Name: name, Aliases: aliases(descs[name])})
+	}
+	return list
+}
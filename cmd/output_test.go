@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+// Runs era with the arguments and stdin returning what was written to stdout
+//
+// Flags are bound to package variables so each is reset to its default first
+func runEra(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	reset := func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	}
+	rootCmd.PersistentFlags().VisitAll(reset)
+	for _, cmd := range append(rootCmd.Commands(), rootCmd) {
+		cmd.Flags().VisitAll(reset)
+	}
+	reportDetected = true
+
+	input, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	input.WriteString(stdin)
+	input.Seek(0, io.SeekStart)
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdinBefore, stdoutBefore := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = input, writer
+	defer func() { os.Stdin, os.Stdout = stdinBefore, stdoutBefore }()
+
+	output := make(chan string)
+	go func() {
+		text, _ := io.ReadAll(reader)
+		output <- string(text)
+	}()
+
+	rootCmd.SilenceUsage = true
+	err = execute(args)
+	writer.Close()
+	return <-output, err
+}
+
+func TestJSONOutput(t *testing.T) {
+	scenarios := []struct {
+		stdin string
+		args  []string
+		// Key expected in each object output or empty when an array is output
+		key string
+	}{
+		{args: []string{"now", "--json", "-t", "UTC"}, key: "unix"},
+		{args: []string{"parse", "--json", "-t", "UTC", "1746799240"}, key: "rfc3339"},
+		{stdin: "1746799240\n2025-05-09\n", args: []string{"parse", "--json", "-t", "UTC"}, key: "rfc3339"},
+		{args: []string{"duration", "--json", "1h30m"}, key: "iso"},
+		{args: []string{"duration", "--json", "-o", "m", "1h30m"}, key: "iso"},
+		{stdin: "1s\n3s\n", args: []string{"duration", "--json"}, key: "count"},
+		{args: []string{"diff", "--json", "-t", "UTC", "2025-01-01", "2025-03-02"}, key: "calendar"},
+		{args: []string{"diff", "--json", "-o", "h", "-t", "UTC", "2025-01-01", "2025-03-02"}, key: "value"},
+		{args: []string{"start-of", "--json", "-t", "UTC", "day", "2025-03-02T13:00:00Z"}, key: "rfc3339"},
+		{args: []string{"end-of", "--json", "-t", "UTC", "month", "2025-03-02T13:00:00Z"}, key: "rfc3339"},
+		{args: []string{"info", "--json", "-t", "UTC", "2025-03-02T13:00:00Z"}, key: "utc"},
+		{args: []string{"tokens", "--json", "-F", "moment"}},
+		{args: []string{"formatter", "--json"}},
+		{args: []string{"parser", "--json"}},
+		{args: []string{"explain", "--json", "-F", "moment", "YYYY"}},
+		{args: []string{"lint", "--json", "-F", "moment", "YYYY"}, key: "problems"},
+		{args: []string{"translate", "--json", "--from", "moment", "--to", "go", "YYYY"}, key: "layout"},
+		{stdin: "at 1746799240 ok\n", args: []string{"rewrite", "--json", "-t", "UTC"}, key: "output"},
+		{args: []string{"parse", "--output", "json", "-t", "UTC", "1746799240"}, key: "rfc3339"},
+		{args: []string{"duration", "-o", "json", "1h30m"}, key: "iso"},
+		{stdin: "1s\n3s\n", args: []string{"duration", "--output", "json"}, key: "count"},
+		{args: []string{"diff", "-o", "json", "-t", "UTC", "2025-01-01", "2025-03-02"}, key: "calendar"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(strings.Join(testCase.args, " "), func(t *testing.T) {
+			output, err := runEra(t, testCase.stdin, testCase.args...)
+			if err != nil {
+				t.Fatalf("Failed to run '%s'\n%s", strings.Join(testCase.args, " "), err)
+			}
+			lines := strings.Split(strings.TrimSpace(output), "\n")
+			for _, line := range lines {
+				if testCase.key == "" {
+					var array []any
+					if err := json.Unmarshal([]byte(line), &array); err != nil {
+						t.Fatalf("Expected a JSON array\nGot: %s\n%s", line, err)
+					}
+					continue
+				}
+				var object map[string]any
+				if err := json.Unmarshal([]byte(line), &object); err != nil {
+					t.Fatalf("Expected a JSON object on each line\nGot: %s\n%s", line, err)
+				}
+				if _, ok := object[testCase.key]; !ok {
+					t.Errorf("Expected the key '%s' in the output\nGot: %s", testCase.key, line)
+				}
+			}
+		})
+	}
+}
+
+func TestOutputInvalid(t *testing.T) {
+	if output, err := runEra(t, "", "parse", "--output", "yaml", "1746799240"); err == nil {
+		t.Errorf("Expected an unsupported output to fail\nGot: %s", output)
+	}
+}
+
+func TestPrintErrorJSON(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderrBefore := os.Stderr
+	os.Stderr = writer
+	JSONOutput = true
+	printError(errors.New("Unable to detect the format of \"nonsense\""))
+	os.Stderr, JSONOutput = stderrBefore, false
+	writer.Close()
+
+	output, _ := io.ReadAll(reader)
+	want := "{\"error\":\"Unable to detect the format of \\\"nonsense\\\"\"}\n"
+	if string(output) != want {
+		t.Errorf("Fail\nGot:  %q\nwant: %q", output, want)
+	}
+}
+
+func TestJSONOutputCSV(t *testing.T) {
+	output, err := runEra(t, "id,when\n1,1746799240\n", "csv", "--json", "-t", "UTC", "-c", "when", "--format", "unix")
+	if err != nil {
+		t.Fatalf("Failed to convert the CSV\n%s", err)
+	}
+	want := "[\"id\",\"when\"]\n[\"1\",\"1746799240\"]\n"
+	if output != want {
+		t.Errorf("Fail\nGot:  %q\nwant: %q", output, want)
+	}
+}
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -10,6 +13,7 @@ import (
 	"gitlab.com/monokuro/era/localiser"
 	"gitlab.com/monokuro/era/parser"
 
+	"github.com/go-playground/locales"
 	"github.com/go-playground/locales/en_GB"
 	"github.com/spf13/cobra"
 )
@@ -17,19 +21,29 @@ import (
 // Format string flag to output the time with
 var Parser string
 
+// How to handle lines of stdin that fail to parse
+var OnError string
+
+// Whether the auto detected format of each time is reported on stderr
+var reportDetected = true
+
 func init() {
 	parseCmd.Flags().StringVarP(&Format, "format", "f", "", "Format to display the datetime with")
 	parseCmd.Flags().StringVarP(&Parser, "formatter", "F", "", "Formatter to interpret and display the supplied datetime with")
 	parseCmd.Flags().StringVarP(&TimeZone, "timezone", "t", "", "Time zone to set the time to")
 	parseCmd.Flags().StringVarP(&Locale, "locale", "l", "", "Locale to use in formatting")
+	parseCmd.Flags().StringVar(&Reference, "reference", "", "Date time the relative formatter describes times from e.g. '2025-05-09 12:00', by default now")
+	parseCmd.Flags().StringVar(&OnError, "on-error", "fail", "How to handle lines of stdin that fail to parse: fail, skip, report or keep")
+	addShiftFlags(parseCmd)
+	addRoundFlags(parseCmd)
 	rootCmd.AddCommand(parseCmd)
 }
 
 var parseCmd = &cobra.Command{
 	Use:   "parse",
 	Short: "Parse a given time",
-	Long:  "Parse a given time in order to manipulate; convert or output it in a different format",
-	Args:  cobra.RangeArgs(1, 3),
+	Long:  "Parse a given time in order to manipulate; convert or output it in a different format\n\nWithout a formatter or layout the format of the time is detected automatically\n\nWithout a time, or with '-' in its place, each line of stdin is parsed and output in turn",
+	Args:  cobra.RangeArgs(0, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		location := time.Now().Local().Location()
 		locale := en_GB.New()
@@ -50,63 +64,56 @@ var parseCmd = &cobra.Command{
 			locale = parsedLocale
 		}
 
-		var dt time.Time
-		switch strings.ToLower(Parser) {
-		case "unix", "timestamp", "ts":
-			unixVal, err := strconv.ParseInt(args[0], 10, 64)
-			if err != nil {
-				return fmt.Errorf("Unable to parse %q as a unix timestamp", args[0])
-			}
-			dt = time.Unix(unixVal, 0).In(location)
-		case "rfc", "rfc3339":
-			time, err := time.Parse(time.RFC3339, args[0])
-			if err != nil {
-				return fmt.Errorf("Unable to parse %q as an RFC3339 string", args[0])
-			}
-			dt = time.In(location)
-		case "iso", "iso8601":
-			time, err := time.Parse("2006-01-02T15:04:05.999Z07:00", args[0])
+		input := "-"
+		if len(args) > 0 {
+			input = args[0]
+		}
+
+		layout := ""
+		if len(args) > 1 {
+			layout = args[1]
+		}
+
+		// A third argument allows the output format to differ from the parsed format
+		parseStr := ""
+		if len(args) > 2 {
+			parseStr = args[2]
+		} else if len(args) > 1 {
+			parseStr = args[1]
+		}
+
+		convert := func(input string) (string, error) {
+			dt, err := ParseTime(input, Parser, layout, locale, location)
 			if err != nil {
-				return fmt.Errorf("Unable to parse %q as an ISO8601 string", args[0])
+				return "", err
 			}
-			dt = time.In(location)
-		case "go", "":
-			formatStr := "2006-01-02 15:04:05.999999999 -0700 MST"
-			if len(args) > 1 {
-				formatStr = args[1]
-			}
-			time, err := time.Parse(formatStr, args[0])
+
+			dt, err = shiftTime(dt)
 			if err != nil {
-				return fmt.Errorf("Unable to parse %q as a Go format string", args[0])
+				return "", err
 			}
-			dt = time.In(location)
-		case "go:strftime", "go:strptime":
-			if len(args) == 1 {
-				return fmt.Errorf("Missing specified format argument")
-			}
-			time, err := parser.GoStrptime.Parse(args[0], args[1])
+
+			dt, err = roundTime(dt)
 			if err != nil {
-				return fmt.Errorf("Failed to parse %q via the strptime parser", args[0])
+				return "", err
 			}
-			dt = time.In(location)
-		case "c", "strftime", "strptime":
-			if len(args) == 1 {
-				return fmt.Errorf("Missing specified format argument")
-			}
-			time, err := parser.CStr.Parse(args[0], args[1])
-			if err != nil {
-				return fmt.Errorf("Failed to parse %q via the strptime parser", args[0])
+
+			formattedTime, err := FormatTime(dt, locale, Format, parseStr)
+			if err != nil || !JSONOutput {
+				return formattedTime, err
 			}
-			dt = time.In(location)
-		default:
-			return fmt.Errorf("%q is not a supported parser", Parser)
+			return marshalOutput(newTimeOutput(input, dt, formattedTime))
 		}
 
-		parseStr := ""
-		if len(args) > 1 {
-			parseStr = args[1]
+		// The usage isn't relevant to inputs that fail to parse
+		cmd.SilenceUsage = true
+
+		// Without a time, or with '-', one is read from each line of stdin
+		if input == "-" {
+			return parseLines(os.Stdin, convert)
 		}
-		formattedTime, err := FormatTime(dt, locale, Format, parseStr)
+
+		formattedTime, err := convert(input)
 		if err != nil {
 			return err
 		}
@@ -115,3 +122,181 @@ var parseCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// Parses an input with the named parser and layout converting the result to the
+// location, shared by every command that reads date times
+//
+// Without a parser or layout the format of the input is detected and reported on
+// stderr
+func ParseTime(input string, parserName string, layout string, locale locales.Translator, location *time.Location) (time.Time, error) {
+	// Without a parser a layout argument is still read as a Go layout, otherwise
+	// the format of the input is detected
+	parserName = strings.ToLower(parserName)
+	if parserName == "" && layout == "" {
+		parserName = "auto"
+	}
+
+	var dt time.Time
+	switch parserName {
+	case "auto", "detect":
+		time, name, err := parser.Detect(input, locale, location)
+		if err != nil {
+			return dt, err
+		}
+		if reportDetected {
+			fmt.Fprintf(os.Stderr, "Detected format: %s\n", name)
+		}
+		dt = time.In(location)
+	case "natural":
+		time, err := parser.ParseNatural(input, time.Now().In(location))
+		if err != nil {
+			return dt, fmt.Errorf("Failed to parse %q as a natural language date: %w", input, err)
+		}
+		dt = time
+	case "unix", "timestamp", "ts":
+		unixVal, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return dt, fmt.Errorf("Unable to parse %q as a unix timestamp", input)
+		}
+		dt = time.Unix(unixVal, 0).In(location)
+	case "rfc", "rfc3339":
+		time, err := time.Parse(time.RFC3339, input)
+		if err != nil {
+			return dt, fmt.Errorf("Unable to parse %q as an RFC3339 string", input)
+		}
+		dt = time.In(location)
+	case "iso", "iso8601":
+		time, err := time.Parse("2006-01-02T15:04:05.999Z07:00", input)
+		if err != nil {
+			return dt, fmt.Errorf("Unable to parse %q as an ISO8601 string", input)
+		}
+		dt = time.In(location)
+	case "go", "":
+		formatStr := "2006-01-02 15:04:05.999999999 -0700 MST"
+		if layout != "" {
+			formatStr = layout
+		}
+		time, err := time.Parse(formatStr, input)
+		if err != nil {
+			return dt, fmt.Errorf("Unable to parse %q as a Go format string", input)
+		}
+		dt = time.In(location)
+	case "go:strftime", "go:strptime":
+		if layout == "" {
+			return dt, fmt.Errorf("Missing specified format argument")
+		}
+		time, err := parser.GoStrptime.Parse(input, layout, locale)
+		if err != nil {
+			return dt, fmt.Errorf("Failed to parse %q via the strptime parser: %w", input, err)
+		}
+		dt = time.In(location)
+	case "moment", "momentjs":
+		if layout == "" {
+			return dt, fmt.Errorf("Missing specified format argument")
+		}
+		time, err := parser.MomentJs.Parse(input, layout, locale)
+		if err != nil {
+			return dt, fmt.Errorf("Failed to parse %q via the moment parser: %w", input, err)
+		}
+		dt = time.In(location)
+	case "luxon":
+		if layout == "" {
+			return dt, fmt.Errorf("Missing specified format argument")
+		}
+		time, err := parser.Luxon.Parse(input, layout, locale)
+		if err != nil {
+			return dt, fmt.Errorf("Failed to parse %q via the luxon parser: %w", input, err)
+		}
+		dt = time.In(location)
+	case "java":
+		if layout == "" {
+			return dt, fmt.Errorf("Missing specified format argument")
+		}
+		time, err := parser.Java.Parse(input, layout, locale)
+		if err != nil {
+			return dt, fmt.Errorf("Failed to parse %q via the java parser: %w", input, err)
+		}
+		dt = time.In(location)
+	case "dotnet", ".net", "csharp":
+		if layout == "" {
+			return dt, fmt.Errorf("Missing specified format argument")
+		}
+		time, err := parser.DotNet.Parse(input, layout, locale)
+		if err != nil {
+			return dt, fmt.Errorf("Failed to parse %q via the .NET parser: %w", input, err)
+		}
+		dt = time.In(location)
+	case "c", "strftime", "strptime":
+		if layout == "" {
+			return dt, fmt.Errorf("Missing specified format argument")
+		}
+		time, err := strftimeHandler.Parse(input, layout, locale)
+		if err != nil {
+			return dt, fmt.Errorf("Failed to parse %q via the strptime parser: %w", input, err)
+		}
+		dt = time.In(location)
+	default:
+		return dt, fmt.Errorf("%q is not a supported parser", parserName)
+	}
+
+	return dt, nil
+}
+
+// Converts each non-empty line of the input printing the results as they are read
+//
+// Lines that fail to convert either stop processing (fail), are dropped (skip), are
+// dropped and reported on stderr (report) or are output unchanged (keep). Reported
+// failures are counted in the returned error once every line has been read
+func parseLines(input io.Reader, convert func(string) (string, error)) error {
+	switch OnError {
+	case "fail", "skip", "report", "keep":
+	default:
+		return fmt.Errorf("%q is not a supported --on-error mode, use fail, skip, report or keep", OnError)
+	}
+
+	// Reporting the format of every line would drown out the output
+	reportDetected = false
+
+	lines, failures := 0, 0
+	scanner := bufio.NewScanner(input)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			// Blank lines are kept so the output lines up with the input
+			if OnError == "keep" && !JSONOutput {
+				fmt.Println()
+			}
+			continue
+		}
+
+		lines++
+		output, err := convert(text)
+		if err != nil {
+			switch OnError {
+			case "fail":
+				return fmt.Errorf("Line %d: %w", line, err)
+			case "report":
+				failures++
+				printError(fmt.Errorf("Line %d: %w", line, err))
+			case "keep":
+				if JSONOutput {
+					if err := printJSON(timeOutput{Input: text, Error: err.Error()}); err != nil {
+						return err
+					}
+					continue
+				}
+				fmt.Println(scanner.Text())
+			}
+			continue
+		}
+		fmt.Println(output)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("Failed to parse %d of %d lines", failures, lines)
+	}
+	return nil
+}
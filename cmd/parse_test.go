@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLines(t *testing.T) {
+	stdin := "1746799240\n\nbad\n1746799300\n"
+	scenarios := []struct {
+		onError string
+		want    string
+		// Error returned once the output is written or empty when none is expected
+		err string
+	}{
+		{onError: "fail", want: "2025-05-09T14:00:40Z\n", err: "Line 3: "},
+		{onError: "skip", want: "2025-05-09T14:00:40Z\n2025-05-09T14:01:40Z\n"},
+		{onError: "report", want: "2025-05-09T14:00:40Z\n2025-05-09T14:01:40Z\n", err: "Failed to parse 1 of 3 lines"},
+		{onError: "keep", want: "2025-05-09T14:00:40Z\n\nbad\n2025-05-09T14:01:40Z\n"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.onError, func(t *testing.T) {
+			output, err := runEra(t, stdin, "parse", "-t", "UTC", "-f", "rfc", "--on-error", testCase.onError)
+			if output != testCase.want {
+				t.Errorf("Fail with --on-error %s\nGot:  %q\nwant: %q", testCase.onError, output, testCase.want)
+			}
+			switch {
+			case testCase.err == "" && err != nil:
+				t.Errorf("Expected no error with --on-error %s\nGot: %s", testCase.onError, err)
+			case testCase.err != "" && (err == nil || !strings.HasPrefix(err.Error(), testCase.err)):
+				t.Errorf("Expected an error starting '%s' with --on-error %s\nGot: %v", testCase.err, testCase.onError, err)
+			}
+		})
+	}
+}
+
+func TestParseLinesInvalidMode(t *testing.T) {
+	if output, err := runEra(t, "1746799240\n", "parse", "--on-error", "ignore"); err == nil {
+		t.Errorf("Expected an unknown --on-error mode to fail\nGot: %s", output)
+	}
+}
@@ -18,10 +18,14 @@ var parserCmd = &cobra.Command{
 	Use:   "parser",
 	Short: "List all available parsers",
 	Long:  "List all parsers available to use with any command that supports a parser argument",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if JSONOutput {
+			return printJSON(nameAliasList(parserMap, func(desc parserDesc) []string { return desc.alias }))
+		}
+
 		var output strings.Builder
 
-		for name, meta := range formatterMap {
+		for name, meta := range parserMap {
 			output.WriteString(fmt.Sprintf("%s\n", name))
 			if len(meta.alias) > 0 {
 				output.WriteString("  aliases:")
@@ -33,6 +37,7 @@ var parserCmd = &cobra.Command{
 		}
 
 		fmt.Print(output.String())
+		return nil
 	},
 }
 
@@ -41,8 +46,10 @@ type parserDesc struct {
 	alias     []string
 }
 
-// luxon and moment are not supported currently
 var parserMap = map[string]parserDesc{
+	"auto": {
+		alias: []string{"detect"},
+	},
 	"unix": {
 		alias: []string{"timestamp", "ts"},
 	},
@@ -52,9 +59,20 @@ var parserMap = map[string]parserDesc{
 	"iso": {
 		alias: []string{"iso8601"},
 	},
-	"go": {formatter: &parser.Go},
+	"go":      {formatter: &parser.Go},
+	"natural": {},
+	"moment": {
+		formatter: &parser.MomentJs,
+		alias:     []string{"momentjs"},
+	},
+	"luxon": {formatter: &parser.Luxon},
+	"java":  {formatter: &parser.Java},
+	"dotnet": {
+		formatter: &parser.DotNet,
+		alias:     []string{".net", "csharp"},
+	},
 	"strftime": {
-		formatter: &parser.CStr,
+		formatter: strftimeHandler,
 		alias:     []string{"c", "strptime"},
 	},
 	"go:strftime": {
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"gitlab.com/monokuro/era/dateutils"
+	"gitlab.com/monokuro/era/localiser"
+
+	"github.com/go-playground/locales/en_GB"
+	"github.com/spf13/cobra"
+)
+
+// Day each week starts on when finding the start or end of a week
+var WeekStart string
+
+func init() {
+	for _, cmd := range []*cobra.Command{startOfCmd, endOfCmd} {
+		cmd.Flags().StringVarP(&Format, "format", "f", "", "Format to display the datetime with")
+		cmd.Flags().StringVarP(&Parser, "formatter", "F", "", "Formatter to interpret and display the supplied datetime with")
+		cmd.Flags().StringVarP(&TimeZone, "timezone", "t", "", "Time zone to find the period in")
+		cmd.Flags().StringVarP(&Locale, "locale", "l", "", "Locale to use in formatting")
+		cmd.Flags().StringVar(&Reference, "reference", "", "Date time the relative formatter describes times from e.g. '2025-05-09 12:00', by default now")
+		cmd.Flags().StringVarP(&WeekStart, "week-start", "w", "monday", "Day each week starts on")
+		rootCmd.AddCommand(cmd)
+	}
+}
+
+var startOfCmd = &cobra.Command{
+	Use:   "start-of <unit> [time] [layout] [output layout]",
+	Short: "Get the start of a period",
+	Long:  "Get the start of the minute, hour, day, week, month, quarter or year containing the current or supplied time",
+	Args:  cobra.RangeArgs(1, 4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPeriod(args, periodStart)
+	},
+}
+
+var endOfCmd = &cobra.Command{
+	Use:   "end-of <unit> [time] [layout] [output layout]",
+	Short: "Get the end of a period",
+	Long:  "Get the last nanosecond of the minute, hour, day, week, month, quarter or year containing the current or supplied time",
+	Args:  cobra.RangeArgs(1, 4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPeriod(args, periodEnd)
+	},
+}
+
+func runPeriod(args []string, boundary func(unit string, dt time.Time, weekStart time.Weekday) (time.Time, error)) error {
+	location := time.Now().Local().Location()
+	locale := en_GB.New()
+
+	if TimeZone != "" {
+		loc, err := time.LoadLocation(TimeZone)
+		if err != nil {
+			return err
+		}
+		location = loc
+	}
+
+	if Locale != "" {
+		parsedLocale, err := localiser.Parse(Locale)
+		if err != nil {
+			return err
+		}
+		locale = parsedLocale
+	}
+
+	weekStart, err := parseWeekday(WeekStart)
+	if err != nil {
+		return err
+	}
+
+	dt := time.Now().In(location)
+	layout := ""
+	if len(args) > 2 {
+		layout = args[2]
+	}
+	if len(args) > 1 {
+		dt, err = ParseTime(args[1], Parser, layout, locale, location)
+		if err != nil {
+			return err
+		}
+	}
+
+	dt, err = boundary(strings.ToLower(args[0]), dt, weekStart)
+	if err != nil {
+		return err
+	}
+
+	// A fourth argument allows the output format to differ from the parsed format
+	parseStr := layout
+	if len(args) > 3 {
+		parseStr = args[3]
+	}
+	formattedTime, err := FormatTime(dt, locale, Format, parseStr)
+	if err != nil {
+		return err
+	}
+	if JSONOutput {
+		input := ""
+		if len(args) > 1 {
+			input = args[1]
+		}
+		return printJSON(newTimeOutput(input, dt, formattedTime))
+	}
+	fmt.Println(formattedTime)
+
+	return nil
+}
+
+func parseWeekday(day string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := weekday.String()
+		if strings.EqualFold(day, name) || strings.EqualFold(day, name[:3]) {
+			return weekday, nil
+		}
+	}
+
+	return time.Sunday, fmt.Errorf("Invalid weekday: %q", day)
+}
+
+// Start of the period containing the date time
+func periodStart(unit string, dt time.Time, weekStart time.Weekday) (time.Time, error) {
+	switch unit {
+	case "minute", "min", "m":
+		return dateutils.Floor(dt, time.Minute), nil
+	case "hour", "h":
+		return dateutils.Floor(dt, time.Hour), nil
+	case "day", "d":
+		return dateutils.DayStart(dt), nil
+	case "week", "w":
+		return dateutils.WeekStart(weekStart, dt), nil
+	case "month", "mo":
+		return dateutils.MonthStart(dt), nil
+	case "quarter", "q":
+		return dateutils.QuarterStart(dt), nil
+	case "year", "y":
+		return dateutils.YearStart(dt), nil
+	}
+
+	return dt, fmt.Errorf("Invalid period: %q", unit)
+}
+
+// Last nanosecond of the period containing the date time
+func periodEnd(unit string, dt time.Time, weekStart time.Weekday) (time.Time, error) {
+	switch unit {
+	case "minute", "min", "m":
+		return dateutils.Floor(dt, time.Minute).Add(time.Minute - 1), nil
+	case "hour", "h":
+		return dateutils.Floor(dt, time.Hour).Add(time.Hour - 1), nil
+	case "day", "d":
+		return time.Date(dt.Year(), dt.Month(), dt.Day(), 23, 59, 59, 999999999, dt.Location()), nil
+	case "week", "w":
+		start := dateutils.WeekStart(weekStart, dt)
+		return time.Date(start.Year(), start.Month(), start.Day()+6, 23, 59, 59, 999999999, dt.Location()), nil
+	case "month", "mo":
+		return dateutils.MonthEnd(dt), nil
+	case "quarter", "q":
+		return dateutils.QuarterEnd(dt), nil
+	case "year", "y":
+		return dateutils.YearEnd(dt), nil
+	}
+
+	return dt, fmt.Errorf("Invalid period: %q", unit)
+}
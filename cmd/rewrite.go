@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gitlab.com/monokuro/era/localiser"
+	"gitlab.com/monokuro/era/parser"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en_GB"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rewriteCmd.Flags().StringVarP(&Format, "format", "f", "", "Format to display each datetime found with")
+	rewriteCmd.Flags().StringVarP(&Parser, "formatter", "F", "", "Formatter to find and display datetimes with, by default common timestamp shapes are detected")
+	rewriteCmd.Flags().StringVarP(&TimeZone, "timezone", "t", "", "Time zone to set each time to")
+	rewriteCmd.Flags().StringVarP(&Locale, "locale", "l", "", "Locale to use in parsing and formatting")
+	rewriteCmd.Flags().StringVar(&Reference, "reference", "", "Date time the relative formatter describes times from e.g. '2025-05-09 12:00', by default now")
+	rootCmd.AddCommand(rewriteCmd)
+}
+
+var rewriteCmd = &cobra.Command{
+	Use:   "rewrite [layout] [output layout]",
+	Short: "Reformat datetimes found within text",
+	Long:  "Reformat every datetime found within each line of stdin leaving the rest of the line untouched e.g. to show the local time of logs while tailing them\n\nWithout a layout timestamps shaped like RFC 3339, ISO 8601, unix timestamps, syslog, Common Log Format or RFC 2822 are detected, otherwise any text matching the layout is replaced",
+	Args:  cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		location := time.Now().Local().Location()
+		locale := en_GB.New()
+
+		if TimeZone != "" {
+			loc, err := time.LoadLocation(TimeZone)
+			if err != nil {
+				return err
+			}
+			location = loc
+		}
+
+		if Locale != "" {
+			parsedLocale, err := localiser.Parse(Locale)
+			if err != nil {
+				return err
+			}
+			locale = parsedLocale
+		}
+
+		layout := ""
+		if len(args) > 0 {
+			layout = args[0]
+		}
+
+		// A second argument allows the output format to differ from the parsed format
+		parseStr := layout
+		if len(args) > 1 {
+			parseStr = args[1]
+		}
+
+		formatter := outputFormatter(layout)
+		match, err := timeMatcher(Parser, layout, locale, location)
+		if err != nil {
+			return err
+		}
+
+		// Reporting the format of every match would drown out the output
+		reportDetected = false
+		// The usage isn't relevant to times that fail to format
+		cmd.SilenceUsage = true
+
+		return rewriteLines(os.Stdin, func(text string) (string, int, error) {
+			dt, consumed, ok := match(text)
+			if !ok {
+				return "", 0, nil
+			}
+
+			formattedTime, err := FormatTime(dt, locale, formatter, parseStr)
+			if err != nil {
+				return "", 0, fmt.Errorf("Unable to format %q: %w", text[:consumed], err)
+			}
+			return formattedTime, consumed, nil
+		})
+	},
+}
+
+// Formatter from the `--format` flag or when missing the formatter times were parsed
+// with if a layout was given, so layouts are reused for output unless told otherwise
+func outputFormatter(layout string) string {
+	if Format != "" || layout == "" {
+		return Format
+	}
+	if Parser == "" {
+		return "go"
+	}
+	return Parser
+}
+
+// Reads a date time from the start of some text returning the number of bytes consumed
+type matcher func(text string) (time.Time, int, bool)
+
+// Creates a matcher for the named parser and layout
+//
+// Without a layout only substrings shaped like common timestamps are tried, otherwise
+// the layout is matched at the start of each word
+func timeMatcher(parserName string, layout string, locale locales.Translator, location *time.Location) (matcher, error) {
+	parserName = strings.ToLower(parserName)
+
+	if layout == "" {
+		switch parserName {
+		case "go:strftime", "go:strptime", "moment", "momentjs", "luxon", "java", "dotnet", ".net", "csharp", "c", "strftime", "strptime":
+			return nil, fmt.Errorf("Missing specified format argument")
+		}
+
+		return func(text string) (time.Time, int, bool) {
+			end := parser.MatchTimestamp(text)
+			if end == 0 {
+				return time.Time{}, 0, false
+			}
+
+			dt, err := ParseTime(text[:end], parserName, "", locale, location)
+			return dt, end, err == nil
+		}, nil
+	}
+
+	prefixMatcher := func(parsePrefix func(input, format string, locale locales.Translator) (time.Time, int, error)) matcher {
+		return func(text string) (time.Time, int, bool) {
+			dt, consumed, err := parsePrefix(text, layout, locale)
+			return dt.In(location), consumed, err == nil && consumed > 0
+		}
+	}
+
+	switch parserName {
+	case "moment", "momentjs":
+		return prefixMatcher(parser.MomentJs.ParsePrefix), nil
+	case "luxon":
+		return prefixMatcher(parser.Luxon.ParsePrefix), nil
+	case "java":
+		if err := parser.Java.ValidateLayout(layout); err != nil {
+			return nil, err
+		}
+		return prefixMatcher(parser.Java.ParsePrefix), nil
+	case "dotnet", ".net", "csharp":
+		if err := parser.DotNet.ValidateLayout(layout); err != nil {
+			return nil, err
+		}
+		return prefixMatcher(parser.DotNet.ParsePrefix), nil
+	case "go:strftime", "go:strptime":
+		return prefixMatcher(parser.GoStrptime.ParsePrefix), nil
+	}
+
+	// Parsers that only accept a whole input are tried against the longest candidates
+	// first, which are rarely much longer than the layout itself
+	maxLen := 2*len(layout) + 32
+	return func(text string) (time.Time, int, bool) {
+		for end := min(len(text), maxLen); end > 0; end-- {
+			if end < len(text) && !utf8.RuneStart(text[end]) {
+				continue
+			}
+			if dt, err := ParseTime(text[:end], parserName, layout, locale, location); err == nil {
+				return dt, end, true
+			}
+		}
+		return time.Time{}, 0, false
+	}, nil
+}
+
+// Replaces matches within each line of the input printing lines as they are read
+//
+// The replace function returns the replacement and the number of bytes it replaces
+// from the start of the text or 0 when nothing matches. Matches must start at the
+// beginning of a word and must not be followed by a digit so that numbers are never
+// split in two
+func rewriteLines(input io.Reader, replace func(text string) (string, int, error)) error {
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		var output strings.Builder
+		prev := ' '
+		for idx := 0; idx < len(line); {
+			if !isWordChar(prev) {
+				replacement, consumed, err := replace(line[idx:])
+				if err != nil {
+					return err
+				}
+				if consumed > 0 && !startsWithDigit(line[idx+consumed:]) {
+					output.WriteString(replacement)
+					prev, _ = utf8.DecodeLastRuneInString(line[:idx+consumed])
+					idx += consumed
+					continue
+				}
+			}
+
+			char, size := utf8.DecodeRuneInString(line[idx:])
+			output.WriteString(line[idx : idx+size])
+			prev = char
+			idx += size
+		}
+		if JSONOutput {
+			if err := printJSON(struct {
+				Input  string `json:"input"`
+				Output string `json:"output"`
+			}{line, output.String()}); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Println(output.String())
+	}
+
+	return scanner.Err()
+}
+
+func isWordChar(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char)
+}
+
+func startsWithDigit(text string) bool {
+	return text != "" && '0' <= text[0] && text[0] <= '9'
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	scenarios := []struct {
+		stdin string
+		args  []string
+		want  string
+	}{
+		{
+			stdin: "at 1746799240, ts=2025-05-09T15:00:40+01:00 level=info\nno times here\n",
+			args:  []string{"rewrite", "-t", "UTC", "-f", "rfc3339"},
+			want:  "at 2025-05-09T14:00:40Z, ts=2025-05-09T14:00:40Z level=info\nno times here\n",
+		},
+		{
+			// Matches followed by a digit or inside a word are left alone
+			stdin: "x 2025-05-0912 x2025-05-09 2025-05-09.\n",
+			args:  []string{"rewrite", "-t", "UTC", "-f", "unix", "2006-01-02"},
+			want:  "x 2025-05-0912 x2025-05-09 1746748800.\n",
+		},
+		{
+			stdin: "on 09/05/2025 15:00 +01:00 ok\n",
+			args:  []string{"rewrite", "-t", "UTC", "-F", "moment", "-f", "rfc3339", "DD/MM/YYYY HH:mm Z"},
+			want:  "on 2025-05-09T14:00:00Z ok\n",
+		},
+		{
+			stdin: "on 09/05/2025 15:00 +01:00 ok\n",
+			args:  []string{"rewrite", "-t", "UTC", "-F", "moment", "DD/MM/YYYY HH:mm Z", "HH:mm"},
+			want:  "on 14:00 ok\n",
+		},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(strings.Join(testCase.args, " "), func(t *testing.T) {
+			output, err := runEra(t, testCase.stdin, testCase.args...)
+			if err != nil {
+				t.Fatalf("Failed to rewrite the input\n%s", err)
+			}
+			if output != testCase.want {
+				t.Errorf("Fail\nGot:  %q\nwant: %q", output, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRewriteInvalid(t *testing.T) {
+	scenarios := [][]string{
+		{"rewrite", "-f", "nonsense"},
+		{"rewrite", "-F", "moment"},
+	}
+
+	for _, args := range scenarios {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			if output, err := runEra(t, "at 1746799240 ok\n", args...); err == nil {
+				t.Errorf("Expected '%s' to fail\nGot: %q", strings.Join(args, " "), output)
+			}
+		})
+	}
+}
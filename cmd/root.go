@@ -3,14 +3,23 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var NoColor bool
 
+// Whether commands output machine readable JSON objects for scripts rather than text
+var JSONOutput bool
+
+// Output format where 'json' is the same as `--json`
+var Output string
+
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&NoColor, "no-color", false, "Disable coloured output")
+	rootCmd.PersistentFlags().BoolVar(&JSONOutput, "json", false, "Output machine readable JSON objects, one per line")
+	rootCmd.PersistentFlags().StringVar(&Output, "output", "", "Output format, 'json' is the same as --json")
 }
 
 var rootCmd = &cobra.Command{
@@ -21,11 +30,47 @@ var rootCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		cmd.Help()
 	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return applyOutput(cmd)
+	},
+	// Errors are printed once by `Execute`
+	SilenceErrors: true,
+}
+
+// Switches to JSON output for `--output json`, including the local `--output` of
+// `duration` and `diff` that takes the place of the global flag
+func applyOutput(cmd *cobra.Command) error {
+	output := cmd.Flags().Lookup("output")
+	if output == nil {
+		return nil
+	}
+
+	global := output == cmd.Root().PersistentFlags().Lookup("output")
+	switch {
+	case strings.EqualFold(output.Value.String(), "json"):
+		JSONOutput = true
+		// Local flags go back to their default units
+		if !global {
+			return output.Value.Set(output.DefValue)
+		}
+	case global && Output != "":
+		return fmt.Errorf("%q is not a supported output, use json", Output)
+	}
+	return nil
 }
 
+// Runs the command from the arguments printing any error on stderr so that stdout
+// only holds the output
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
+	if err := execute(os.Args[1:]); err != nil {
+		printError(err)
 		os.Exit(1)
 	}
 }
+
+// Runs the command from the arguments where durations starting with a sign are read as
+// arguments of `era duration`
+func execute(args []string) error {
+	rootCmd.SetArgs(signedDurationArgs(args))
+	return rootCmd.Execute()
+}
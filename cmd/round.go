@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gitlab.com/monokuro/era/dateutils"
+
+	"github.com/spf13/cobra"
+)
+
+// Durations to round a date time to a multiple of on the wall clock
+var RoundTo string
+var FloorTo string
+var CeilTo string
+
+// Adds the flags to round the date time of a command before it is formatted
+func addRoundFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&RoundTo, "round", "", "Round to the nearest multiple of a duration on the wall clock e.g. '15m'")
+	cmd.Flags().StringVar(&FloorTo, "floor", "", "Round down to a multiple of a duration on the wall clock e.g. '15m'")
+	cmd.Flags().StringVar(&CeilTo, "ceil", "", "Round up to a multiple of a duration on the wall clock e.g. '15m'")
+	cmd.MarkFlagsMutuallyExclusive("round", "floor", "ceil")
+}
+
+// Applies the durations from the `--round`, `--floor` and `--ceil` flags to a date time
+func roundTime(dt time.Time) (time.Time, error) {
+	for _, rounding := range []struct {
+		flag  string
+		value string
+		round func(time.Time, time.Duration) time.Time
+	}{
+		{"round", RoundTo, dateutils.Round},
+		{"floor", FloorTo, dateutils.Floor},
+		{"ceil", CeilTo, dateutils.Ceil},
+	} {
+		if rounding.value == "" {
+			continue
+		}
+
+		unit, err := dateutils.ParseDuration(strings.TrimSpace(rounding.value))
+		if err != nil {
+			return dt, fmt.Errorf("Unable to parse duration to %s to %q: %w", rounding.flag, rounding.value, err)
+		}
+		if unit < 1 {
+			return dt, fmt.Errorf("Duration to %s to must be positive", rounding.flag)
+		}
+
+		dt = rounding.round(dt, time.Duration(unit))
+	}
+
+	return dt, nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gitlab.com/monokuro/era/dateutils"
+
+	"github.com/spf13/cobra"
+)
+
+// Durations including calendar units to add to or subtract from a date time
+var AddDur string
+var SubDur string
+
+// Adds the flags to shift the date time of a command before it is formatted
+func addShiftFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&AddDur, "add", "", "Duration to add such as '1h30m' including calendar units days, weeks, months and years e.g. '1mo 2w'")
+	cmd.Flags().StringVar(&SubDur, "sub", "", "Duration to subtract such as '1h30m' including calendar units days, weeks, months and years e.g. '1y'")
+}
+
+// Applies the durations from the `--add` and `--sub` flags to a date time
+//
+// Calendar units are applied before exact units, see `dateutils.AddDate` for the
+// handling of month ends and daylight saving changes
+func shiftTime(dt time.Time) (time.Time, error) {
+	if AddDur != "" {
+		dur, err := dateutils.ParseCalendarDuration(strings.TrimSpace(AddDur))
+		if err != nil {
+			return dt, fmt.Errorf("Unable to parse duration to add %q: %w", AddDur, err)
+		}
+		dt = dur.AddTo(dt)
+	}
+
+	if SubDur != "" {
+		dur, err := dateutils.ParseCalendarDuration(strings.TrimSpace(SubDur))
+		if err != nil {
+			return dt, fmt.Errorf("Unable to parse duration to subtract %q: %w", SubDur, err)
+		}
+		dt = dur.Negate().AddTo(dt)
+	}
+
+	return dt, nil
+}
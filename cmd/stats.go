@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gitlab.com/monokuro/era/dateutils"
+
+	"github.com/go-playground/locales"
+)
+
+// Column of CSV or TSV input to read durations from by name or 1-based index
+var Column string
+
+// Comma separated percentiles to include in the summary of durations read from stdin
+var Percentiles string
+
+func init() {
+	durationCmd.Flags().StringVarP(&Column, "column", "c", "", "Column of CSV input on stdin to read durations from by header name or 1-based index")
+	durationCmd.Flags().StringVarP(&Delimiter, "delimiter", "d", ",", "Separator between columns of stdin input with --column, use 'tab' for TSV")
+	durationCmd.Flags().StringVarP(&Percentiles, "percentiles", "p", "90,95,99", "Comma separated percentiles to include in the summary of durations read from stdin")
+}
+
+// Reads a duration from each line or CSV row of the input and prints the count, sum,
+// mean, median, min, max and percentiles formatted following the `--output` flag
+func durationStats(input io.Reader, locale locales.Translator) error {
+	durations, err := readDurations(input)
+	if err != nil {
+		return err
+	}
+	if len(durations) == 0 {
+		return fmt.Errorf("No durations were provided on stdin")
+	}
+	slices.Sort(durations)
+
+	sum := 0.
+	for _, dur := range durations {
+		sum += dur
+	}
+
+	stats := []struct {
+		name  string
+		value float64
+	}{
+		{"sum", sum},
+		{"mean", math.Round(sum / float64(len(durations)))},
+		{"median", percentile(durations, 50)},
+		{"min", durations[0]},
+		{"max", durations[len(durations)-1]},
+	}
+	for _, pctStr := range strings.Split(Percentiles, ",") {
+		if strings.TrimSpace(pctStr) == "" {
+			continue
+		}
+		pct, err := strconv.ParseFloat(strings.TrimSpace(pctStr), 64)
+		if err != nil || pct < 0 || pct > 100 {
+			return fmt.Errorf("Percentiles must be numbers between 0 and 100: %q", pctStr)
+		}
+		stats = append(stats, struct {
+			name  string
+			value float64
+		}{fmt.Sprintf("p%v", pct), percentile(durations, pct)})
+	}
+
+	if JSONOutput {
+		output := map[string]any{"count": len(durations)}
+		for _, stat := range stats {
+			durOutput, err := newDurationOutput(stat.value, locale)
+			if err != nil {
+				return err
+			}
+			output[stat.name] = durOutput
+		}
+		return printJSON(output)
+	}
+
+	fmt.Printf("%-8s%d\n", "count", len(durations))
+	for _, stat := range stats {
+		output, err := formatDuration(stat.value, locale)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-8s%s\n", stat.name, output)
+	}
+
+	return nil
+}
+
+// Reads durations in nanoseconds from each non-empty line or from a column of CSV
+// rows when `--column` is set
+func readDurations(input io.Reader) ([]float64, error) {
+	durations := []float64{}
+	add := func(line int, value string) error {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil
+		}
+
+		dur, isDuration, err := dateutils.EvaluateDuration(value)
+		if err == nil && !isDuration {
+			err = fmt.Errorf("Expected a duration rather than a number")
+		}
+		if err != nil {
+			return fmt.Errorf("Line %d: unable to parse %q: %w", line, value, err)
+		}
+
+		durations = append(durations, dur)
+		return nil
+	}
+
+	if Column == "" {
+		scanner := bufio.NewScanner(input)
+		for line := 1; scanner.Scan(); line++ {
+			if err := add(line, scanner.Text()); err != nil {
+				return nil, err
+			}
+		}
+		return durations, scanner.Err()
+	}
+
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = -1
+	comma, err := parseDelimiter()
+	if err != nil {
+		return nil, err
+	}
+	reader.Comma = comma
+
+	// A column index has no header row whereas a name is looked up in the first row
+	column, err := strconv.Atoi(Column)
+	hasHeader := err != nil
+	column--
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hasHeader && line == 1 {
+			column = slices.Index(record, Column)
+			if column == -1 {
+				return nil, fmt.Errorf("Column %q was not found in the header", Column)
+			}
+			continue
+		}
+		if column < 0 || column >= len(record) {
+			return nil, fmt.Errorf("Line %d: column %s is out of range", line, Column)
+		}
+
+		if err := add(line, record[column]); err != nil {
+			return nil, err
+		}
+	}
+
+	return durations, nil
+}
+
+// Linearly interpolated percentile of sorted durations rounded to the nanosecond
+func percentile(sorted []float64, pct float64) float64 {
+	rank := pct / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return math.Round(sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower)))
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDurationStats(t *testing.T) {
+	scenarios := []struct {
+		stdin string
+		args  []string
+		want  string
+	}{
+		{
+			stdin: "1s\n2s\n\n3s\n4s\n10s\n",
+			args:  []string{"duration", "-o", "s", "-p", "50,90"},
+			want:  "count   5\nsum     20\nmean    4\nmedian  3\nmin     1\nmax     10\np50     3\np90     7.6\n",
+		},
+		{
+			stdin: "id,took\n1,1s\n2,3s\n",
+			args:  []string{"duration", "-c", "took", "-o", "s", "-p", ""},
+			want:  "count   2\nsum     4\nmean    2\nmedian  2\nmin     1\nmax     3\n",
+		},
+		{
+			stdin: "1,1s\n2,3s\n",
+			args:  []string{"duration", "-c", "2", "-o", "s", "-p", ""},
+			want:  "count   2\nsum     4\nmean    2\nmedian  2\nmin     1\nmax     3\n",
+		},
+		{
+			stdin: "1\t500ms\n2\t1s\n3\t1.5s\n",
+			args:  []string{"duration", "-c", "2", "-d", "tab", "-p", "25"},
+			want:  "count   3\nsum     3000\nmean    1000\nmedian  1000\nmin     500\nmax     1500\np25     750\n",
+		},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(strings.Join(testCase.args, " "), func(t *testing.T) {
+			output, err := runEra(t, testCase.stdin, testCase.args...)
+			if err != nil {
+				t.Fatalf("Failed to summarise the durations\n%s", err)
+			}
+			if output != testCase.want {
+				t.Errorf("Fail\nGot:  %q\nwant: %q", output, testCase.want)
+			}
+		})
+	}
+}
+
+func TestDurationStatsInvalid(t *testing.T) {
+	scenarios := []struct {
+		stdin string
+		args  []string
+		want  string
+	}{
+		{stdin: "1s\n2\n", args: []string{"duration"}, want: "Line 2: unable to parse \"2\": Expected a duration rather than a number"},
+		{stdin: "1s\nsoon\n", args: []string{"duration"}, want: "Line 2: unable to parse \"soon\""},
+		{stdin: "\n\n", args: []string{"duration"}, want: "No durations were provided on stdin"},
+		{stdin: "id,took\n1,1s\n", args: []string{"duration", "-c", "taken"}, want: "Column \"taken\" was not found in the header"},
+		{stdin: "1,1s\n", args: []string{"duration", "-c", "3"}, want: "Line 1: column 3 is out of range"},
+		{stdin: "1s\n", args: []string{"duration", "-p", "101"}, want: "Percentiles must be numbers between 0 and 100"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(strings.Join(testCase.args, " "), func(t *testing.T) {
+			output, err := runEra(t, testCase.stdin, testCase.args...)
+			if err == nil || !strings.HasPrefix(err.Error(), testCase.want) {
+				t.Errorf("Expected the error '%s'\nGot: %v %q", testCase.want, err, output)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1e9, 2e9, 3e9, 4e9, 10e9}
+	scenarios := []struct {
+		pct  float64
+		want float64
+	}{
+		{pct: 0, want: 1e9},
+		{pct: 25, want: 2e9},
+		{pct: 50, want: 3e9},
+		{pct: 90, want: 7.6e9},
+		{pct: 95, want: 8.8e9},
+		{pct: 100, want: 10e9},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := percentile(sorted, testCase.pct); got != testCase.want {
+				t.Errorf("Fail finding p%v\nGot:  %v\nwant: %v", testCase.pct, got, testCase.want)
+			}
+		})
+	}
+
+	if got := percentile([]float64{5}, 90); got != 5 {
+		t.Errorf("Fail finding p90 of a single duration\nGot:  %v\nwant: 5", got)
+	}
+}
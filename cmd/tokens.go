@@ -25,8 +25,12 @@ var tokensCmd = &cobra.Command{
 			selectedParser = &parser.MomentJs
 		case "luxon":
 			selectedParser = &parser.Luxon
+		case "java":
+			selectedParser = &parser.Java
+		case "dotnet", ".net", "csharp":
+			selectedParser = &parser.DotNet
 		case "c", "strftime", "strptime":
-			selectedParser = &parser.CStr
+			selectedParser = strftimeHandler
 		case "go:strptime", "go:strftime":
 			selectedParser = &parser.GoStrptime
 		case "go":
@@ -36,6 +40,9 @@ var tokensCmd = &cobra.Command{
 		default:
 			return fmt.Errorf("Parser %q is not supported", Parser)
 		}
+		if JSONOutput {
+			return printJSON(selectedParser.Tokens())
+		}
 		if NoColor {
 			fmt.Print(selectedParser.TokenDesc())
 		} else {
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gitlab.com/monokuro/era/parser"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Dialects to translate a layout from and to
+var From, To string
+
+func init() {
+	translateCmd.Flags().StringVar(&From, "from", "", "Formatter the layout is written for: moment, luxon, strftime or go")
+	translateCmd.Flags().StringVar(&To, "to", "", "Formatter to translate the layout to: moment, luxon, strftime or go")
+	translateCmd.MarkFlagRequired("from")
+	translateCmd.MarkFlagRequired("to")
+	rootCmd.AddCommand(translateCmd)
+}
+
+var translateCmd = &cobra.Command{
+	Use:   "translate <layout>",
+	Short: "Translate a layout between formatters",
+	Long:  "Translate a layout written for one formatter into the equivalent layout for another e.g. from moment's 'Do MMM YYYY' to strftime\n\nTokens without an exact equivalent are replaced by the closest one or kept as literal text with a warning on stderr",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		layout, warnings, err := parser.Translate(args[0], translateDialect(From), translateDialect(To))
+		if err != nil {
+			return err
+		}
+
+		if JSONOutput {
+			return printJSON(struct {
+				Layout   string   `json:"layout"`
+				Warnings []string `json:"warnings,omitempty"`
+			}{layout, warnings})
+		}
+
+		for _, warning := range warnings {
+			prefix := "Warning:"
+			if !NoColor {
+				prefix = color.YellowString(prefix)
+			}
+			fmt.Fprintln(os.Stderr, prefix, warning)
+		}
+		fmt.Println(layout)
+
+		return nil
+	},
+}
+
+// Resolves aliases of formatters to the dialect names used when translating
+func translateDialect(name string) string {
+	switch name = strings.ToLower(name); name {
+	case "momentjs":
+		return "moment"
+	case "c", "strptime", "go:strftime", "go:strptime":
+		return "strftime"
+	}
+	return name
+}
@@ -39,16 +39,116 @@ func WeekEnd(day time.Weekday, t time.Time) time.Time {
 //
 // If the desired weekday is the current day then the current day will be returned
 func WeekStart(day time.Weekday, t time.Time) time.Time {
-	daysUntilDay := (t.Weekday() + day) % 7
+	daysUntilDay := (7 + t.Weekday() - day) % 7
 	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).AddDate(0, 0, -int(daysUntilDay))
 }
 
 // Quarter of the provided year in the range of 1-4
 func YearQuarter(t time.Time) int {
-	daysInYear := float64(YearEnd(t).YearDay())
-	quarterZeroed := (float64(t.YearDay()) / daysInYear) * 4
-	if quarterZeroed == 4 {
-		quarterZeroed = 3
+	return int(t.Month()-1)/3 + 1
+}
+
+// Equivalent to midnight of the first day of the quarter for the provided date time
+// taking into account the location
+func QuarterStart(t time.Time) time.Time {
+	return time.Date(t.Year(), time.Month((YearQuarter(t)-1)*3+1), 1, 0, 0, 0, 0, t.Location())
+}
+
+// Equivalent to 1 nanosecond before midnight of the first day of the following quarter
+// for the provided date time taking into account the location
+func QuarterEnd(t time.Time) time.Time {
+	return time.Date(t.Year(), time.Month(YearQuarter(t)*3+1), 0, 23, 59, 59, 999999999, t.Location())
+}
+
+// Equivalent to midnight of the first day of the month for the provided date time
+// taking into account the location
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// Equivalent to 1 nanosecond before midnight of the first day of the following month
+// for the provided date time taking into account the location
+func MonthEnd(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()+1, 0, 23, 59, 59, 999999999, t.Location())
+}
+
+// Whether the year has 366 days in the proleptic Gregorian calendar
+func IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// Number of days in the month of the provided date time
+func DaysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Astronomical Julian day of the instant where day 0 started at noon UTC on
+// January 1st 4713 BC in the Julian calendar
+func JulianDay(t time.Time) float64 {
+	// The unix epoch is Julian day 2440587.5
+	day := float64(24 * time.Hour / time.Second)
+	return float64(t.Unix())/day + float64(t.Nanosecond())/float64(24*time.Hour) + 2440587.5
+}
+
+// Shifts a date time by a number of years, months and days keeping the time of day
+//
+// Unlike `time.Time.AddDate` the day is clamped to the end of the month rather than
+// overflowing into the next e.g. January 31st plus 1 month is February 28th. A time
+// of day skipped by a daylight saving change moves forward by the length of the
+// change and a time of day that occurs twice uses the earlier of the two
+func AddDate(t time.Time, years, months, days int) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+
+	year, month = year+years, month+time.Month(months)
+	monthEnd := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	year, month = monthEnd.Year(), monthEnd.Month()
+	day = min(day, monthEnd.Day())
+
+	dt := time.Date(year, month, day+days, hour, minute, second, t.Nanosecond(), t.Location())
+
+	// When the clocks go back the earlier instant is one offset change before
+	_, offset := dt.Zone()
+	if _, prevOffset := dt.Add(-24 * time.Hour).Zone(); prevOffset > offset {
+		earlier := dt.Add(-time.Duration(prevOffset-offset) * time.Second)
+		if earlier.Hour() == dt.Hour() && earlier.Minute() == dt.Minute() && earlier.Day() == dt.Day() {
+			dt = earlier
+		}
 	}
-	return int(quarterZeroed + 1)
+
+	return dt
+}
+
+// Rounds a date time down to a multiple of `unit` on the wall clock e.g. 15 minutes
+// rounds 10:44 down to 10:30 in any location
+//
+// Multiples are counted from midnight of January 1st year 1 so units that divide a
+// day evenly align to midnight
+func Floor(t time.Time, unit time.Duration) time.Time {
+	return fromWallClock(wallClock(t).Truncate(unit), t.Location())
+}
+
+// Rounds a date time up to a multiple of `unit` on the wall clock, see `Floor`
+func Ceil(t time.Time, unit time.Duration) time.Time {
+	wall := wallClock(t)
+	floor := wall.Truncate(unit)
+	if floor.Equal(wall) {
+		return t
+	}
+	return fromWallClock(floor.Add(unit), t.Location())
+}
+
+// Rounds a date time to the nearest multiple of `unit` on the wall clock with halfway
+// values rounding up, see `Floor`
+func Round(t time.Time, unit time.Duration) time.Time {
+	return fromWallClock(wallClock(t).Round(unit), t.Location())
+}
+
+// Date time in UTC with the same wall clock as the provided date time
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func fromWallClock(t time.Time, location *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location)
 }
@@ -0,0 +1,205 @@
+package dateutils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddDate(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	scenarios := []struct {
+		dt                  time.Time
+		years, months, days int
+		want                time.Time
+	}{
+		{dt: time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC), months: 1, want: time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{dt: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC), months: 1, want: time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)},
+		{dt: time.Date(2025, 3, 31, 10, 0, 0, 0, time.UTC), months: -1, want: time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{dt: time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC), months: 1, days: 1, want: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{dt: time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC), years: 1, want: time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{dt: time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC), years: 4, want: time.Date(2028, 2, 29, 10, 0, 0, 0, time.UTC)},
+		{dt: time.Date(2023, 2, 28, 10, 0, 0, 0, time.UTC), years: 1, want: time.Date(2024, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{dt: time.Date(2025, 12, 31, 10, 0, 0, 0, time.UTC), days: 1, want: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)},
+		{dt: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), months: 13, want: time.Date(2026, 2, 15, 10, 0, 0, 0, time.UTC)},
+		// GMT to BST keeps the time of day
+		{dt: time.Date(2025, 3, 29, 12, 0, 0, 0, london), days: 1, want: time.Date(2025, 3, 30, 11, 0, 0, 0, time.UTC)},
+		// BST to GMT keeps the time of day
+		{dt: time.Date(2025, 10, 25, 12, 0, 0, 0, london), days: 1, want: time.Date(2025, 10, 26, 12, 0, 0, 0, time.UTC)},
+		// Skipped by the clocks going forward so moves on by an hour
+		{dt: time.Date(2025, 3, 29, 1, 30, 0, 0, london), days: 1, want: time.Date(2025, 3, 30, 1, 30, 0, 0, time.UTC)},
+		// Occurs twice when the clocks go back so uses the earlier BST time
+		{dt: time.Date(2025, 10, 25, 1, 30, 0, 0, london), days: 1, want: time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC)},
+		{dt: time.Date(2025, 11, 26, 1, 30, 0, 0, london), months: -1, want: time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC)},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got := AddDate(testCase.dt, testCase.years, testCase.months, testCase.days)
+			if got.Compare(testCase.want) != 0 {
+				t.Errorf("Fail adding %dy %dmo %dd to %s\nGot:  %s\nwant: %s", testCase.years, testCase.months, testCase.days, testCase.dt, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRounding(t *testing.T) {
+	kolkata := time.FixedZone("IST", 19800)
+	london, _ := time.LoadLocation("Europe/London")
+	dt := time.Date(2025, 5, 9, 10, 44, 30, 0, kolkata)
+	scenarios := []struct {
+		dt                 time.Time
+		unit               time.Duration
+		floor, ceil, round time.Time
+	}{
+		{
+			dt: dt, unit: 15 * time.Minute,
+			floor: time.Date(2025, 5, 9, 10, 30, 0, 0, kolkata),
+			ceil:  time.Date(2025, 5, 9, 10, 45, 0, 0, kolkata),
+			round: time.Date(2025, 5, 9, 10, 45, 0, 0, kolkata),
+		},
+		{
+			dt: dt, unit: time.Minute,
+			floor: time.Date(2025, 5, 9, 10, 44, 0, 0, kolkata),
+			ceil:  time.Date(2025, 5, 9, 10, 45, 0, 0, kolkata),
+			round: time.Date(2025, 5, 9, 10, 45, 0, 0, kolkata),
+		},
+		{
+			dt: dt, unit: 24 * time.Hour,
+			floor: time.Date(2025, 5, 9, 0, 0, 0, 0, kolkata),
+			ceil:  time.Date(2025, 5, 10, 0, 0, 0, 0, kolkata),
+			round: time.Date(2025, 5, 9, 0, 0, 0, 0, kolkata),
+		},
+		{
+			dt: time.Date(2025, 5, 9, 10, 45, 0, 0, kolkata), unit: 15 * time.Minute,
+			floor: time.Date(2025, 5, 9, 10, 45, 0, 0, kolkata),
+			ceil:  time.Date(2025, 5, 9, 10, 45, 0, 0, kolkata),
+			round: time.Date(2025, 5, 9, 10, 45, 0, 0, kolkata),
+		},
+		// Days align to midnight on the wall clock when the clocks go forward
+		{
+			dt: time.Date(2025, 3, 30, 13, 0, 0, 0, london), unit: 24 * time.Hour,
+			floor: time.Date(2025, 3, 30, 0, 0, 0, 0, london),
+			ceil:  time.Date(2025, 3, 31, 0, 0, 0, 0, london),
+			round: time.Date(2025, 3, 31, 0, 0, 0, 0, london),
+		},
+		{
+			dt: time.Date(2025, 12, 31, 23, 59, 59, 999_999_999, time.UTC), unit: time.Second,
+			floor: time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC),
+			ceil:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			round: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := Floor(testCase.dt, testCase.unit); got.Compare(testCase.floor) != 0 {
+				t.Errorf("Fail flooring %s to %s\nGot:  %s\nwant: %s", testCase.dt, testCase.unit, got, testCase.floor)
+			}
+			if got := Ceil(testCase.dt, testCase.unit); got.Compare(testCase.ceil) != 0 {
+				t.Errorf("Fail ceiling %s to %s\nGot:  %s\nwant: %s", testCase.dt, testCase.unit, got, testCase.ceil)
+			}
+			if got := Round(testCase.dt, testCase.unit); got.Compare(testCase.round) != 0 {
+				t.Errorf("Fail rounding %s to %s\nGot:  %s\nwant: %s", testCase.dt, testCase.unit, got, testCase.round)
+			}
+		})
+	}
+}
+
+func TestCalendarProperties(t *testing.T) {
+	scenarios := []struct {
+		dt          time.Time
+		leapYear    bool
+		daysInMonth int
+	}{
+		{dt: time.Date(2000, 2, 1, 0, 0, 0, 0, time.UTC), leapYear: true, daysInMonth: 29},
+		{dt: time.Date(1900, 2, 1, 0, 0, 0, 0, time.UTC), daysInMonth: 28},
+		{dt: time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC), leapYear: true, daysInMonth: 29},
+		{dt: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), daysInMonth: 28},
+		{dt: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), daysInMonth: 30},
+		{dt: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), daysInMonth: 31},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := IsLeapYear(testCase.dt.Year()); got != testCase.leapYear {
+				t.Errorf("Fail checking %d is a leap year\nGot:  %t\nwant: %t", testCase.dt.Year(), got, testCase.leapYear)
+			}
+			if got := DaysInMonth(testCase.dt); got != testCase.daysInMonth {
+				t.Errorf("Fail counting the days in %s\nGot:  %d\nwant: %d", testCase.dt, got, testCase.daysInMonth)
+			}
+		})
+	}
+}
+
+func TestJulianDay(t *testing.T) {
+	kolkata := time.FixedZone("IST", 19800)
+	scenarios := []struct {
+		dt   time.Time
+		want float64
+	}{
+		{dt: time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), want: 2451545},
+		{dt: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), want: 2440587.5},
+		{dt: time.Date(2000, 1, 1, 17, 30, 0, 0, kolkata), want: 2451545},
+		{dt: time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC), want: 2451545.5},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := JulianDay(testCase.dt); got != testCase.want {
+				t.Errorf("Fail finding the Julian day of %s\nGot:  %f\nwant: %f", testCase.dt, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestPeriodBounds(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	scenarios := []struct {
+		dt                                             time.Time
+		quarterStart, quarterEnd, monthStart, monthEnd time.Time
+	}{
+		{
+			dt:           time.Date(2025, 5, 9, 15, 0, 40, 0, london),
+			quarterStart: time.Date(2025, 4, 1, 0, 0, 0, 0, london),
+			quarterEnd:   time.Date(2025, 6, 30, 23, 59, 59, 999_999_999, london),
+			monthStart:   time.Date(2025, 5, 1, 0, 0, 0, 0, london),
+			monthEnd:     time.Date(2025, 5, 31, 23, 59, 59, 999_999_999, london),
+		},
+		{
+			dt:           time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+			quarterStart: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			quarterEnd:   time.Date(2024, 3, 31, 23, 59, 59, 999_999_999, time.UTC),
+			monthStart:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			monthEnd:     time.Date(2024, 2, 29, 23, 59, 59, 999_999_999, time.UTC),
+		},
+		{
+			dt:           time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC),
+			quarterStart: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			quarterEnd:   time.Date(2025, 12, 31, 23, 59, 59, 999_999_999, time.UTC),
+			monthStart:   time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+			monthEnd:     time.Date(2025, 12, 31, 23, 59, 59, 999_999_999, time.UTC),
+		},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := QuarterStart(testCase.dt); got.Compare(testCase.quarterStart) != 0 {
+				t.Errorf("Fail finding the start of the quarter of %s\nGot:  %s\nwant: %s", testCase.dt, got, testCase.quarterStart)
+			}
+			if got := QuarterEnd(testCase.dt); got.Compare(testCase.quarterEnd) != 0 {
+				t.Errorf("Fail finding the end of the quarter of %s\nGot:  %s\nwant: %s", testCase.dt, got, testCase.quarterEnd)
+			}
+			if got := MonthStart(testCase.dt); got.Compare(testCase.monthStart) != 0 {
+				t.Errorf("Fail finding the start of the month of %s\nGot:  %s\nwant: %s", testCase.dt, got, testCase.monthStart)
+			}
+			if got := MonthEnd(testCase.dt); got.Compare(testCase.monthEnd) != 0 {
+				t.Errorf("Fail finding the end of the month of %s\nGot:  %s\nwant: %s", testCase.dt, got, testCase.monthEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package dateutils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parses a provided duration string into a provided output following the `time`
+// package definition for time: 1 = 1 nanosecond
+//
+// A sign applies to every following component until the next sign so '-1h30m' is
+// negative 90 minutes and '1h-5m' is 55 minutes. ISO 8601 durations such as
+// 'P1DT2H30M' are also accepted where days are 24 hours and weeks 7 days
+func ParseDuration(durStr string) (float64, error) {
+	total := 0.
+	err := components(durStr)(durStr, func(durVal float64, unit string) error {
+		if calendar, ok := calendarUnits[unit]; ok && (calendar.Years != 0 || calendar.Months != 0) {
+			return fmt.Errorf("Years and months cannot be converted to an exact duration")
+		}
+
+		durUnit, err := DurationUnit(unit)
+		if err != nil {
+			return err
+		}
+
+		total += durVal * float64(durUnit)
+		return nil
+	})
+
+	return total, err
+}
+
+// Selects how to split a duration string into value and unit pairs
+func components(durStr string) func(string, func(float64, string) error) error {
+	unsigned := strings.TrimLeft(durStr, "+-")
+	if strings.HasPrefix(unsigned, "P") || strings.HasPrefix(unsigned, "p") {
+		return isoDurationComponents
+	}
+	return durationComponents
+}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:([\d.,]+)Y)?(?:([\d.,]+)M)?(?:([\d.,]+)W)?(?:([\d.,]+)D)?(?:T(?:([\d.,]+)H)?(?:([\d.,]+)M)?(?:([\d.,]+)S)?)?$`)
+
+// Units of each group in `isoDurationPattern`
+var isoDurationUnits = []string{"y", "mo", "w", "d", "h", "m", "s"}
+
+// Splits an ISO 8601 duration e.g. 'P1DT2H30M' into each value and unit pair
+// using the same units as `durationComponents`
+//
+// A leading sign negates every component e.g. '-PT1H30M'
+func isoDurationComponents(durStr string, fn func(durVal float64, unit string) error) error {
+	sign := 1.
+	upper := strings.ToUpper(durStr)
+	if rest, ok := strings.CutPrefix(upper, "-"); ok {
+		sign, upper = -1, rest
+	} else if rest, ok := strings.CutPrefix(upper, "+"); ok {
+		upper = rest
+	}
+
+	match := isoDurationPattern.FindStringSubmatch(upper)
+	if match == nil || upper == "P" || strings.HasSuffix(upper, "T") {
+		return fmt.Errorf("Invalid ISO 8601 duration %q", durStr)
+	}
+
+	for idx, value := range match[1:] {
+		if value == "" {
+			continue
+		}
+
+		// Either a comma or period may separate the fraction
+		durVal, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+		if err != nil {
+			return fmt.Errorf("Invalid duration value %q", value)
+		}
+
+		if err := fn(sign*durVal, isoDurationUnits[idx]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Splits a duration string into each value and unit pair e.g. '1h 30m' calls `fn`
+// with (1, "h") followed by (30, "m")
+//
+// Values are negated after a '-' until a '+' e.g. '1h-5m' calls `fn` with (1, "h")
+// followed by (-5, "m")
+func durationComponents(durStr string, fn func(durVal float64, unit string) error) error {
+	valStack := []rune{}
+	unitStack := []rune{}
+	sign := 1.
+	signed := false
+
+	parse := func(valStack, unitStack []rune) error {
+		durVal, err := strconv.ParseFloat(string(valStack), 64)
+		if err != nil {
+			return fmt.Errorf("Invalid duration value %q", string(valStack))
+		}
+
+		if len(unitStack) == 0 {
+			return fmt.Errorf("Units are required")
+		}
+
+		return fn(sign*durVal, string(unitStack))
+	}
+
+	for _, char := range durStr {
+		switch {
+		case '0' <= char && char <= '9' || char == '.':
+			if len(unitStack) > 0 {
+				if err := parse(valStack, unitStack); err != nil {
+					return err
+				}
+
+				valStack = []rune{}
+				unitStack = []rune{}
+			}
+
+			valStack = append(valStack, char)
+			signed = false
+		case char == '+' || char == '-':
+			if signed {
+				return fmt.Errorf("Invalid character: %q", char)
+			}
+			if len(valStack) > 0 || len(unitStack) > 0 {
+				if err := parse(valStack, unitStack); err != nil {
+					return err
+				}
+
+				valStack = []rune{}
+				unitStack = []rune{}
+			}
+
+			sign = 1
+			if char == '-' {
+				sign = -1
+			}
+			signed = true
+		case 'A' <= char && char <= 'Z':
+		case 'a' <= char && char <= 'z' || char == 'µ' || char == 'μ':
+			unitStack = append(unitStack, char)
+		case char == ' ' && len(valStack) > 0 && len(unitStack) == 0:
+			return fmt.Errorf("Units are required for space separated durations")
+		case char == ' ' || char == '_':
+			continue
+		default:
+			return fmt.Errorf("Invalid character: %q", char)
+		}
+	}
+
+	if signed {
+		return fmt.Errorf("Expected a duration after the sign")
+	}
+	if len(valStack) > 0 || len(unitStack) > 0 {
+		return parse(valStack, unitStack)
+	}
+
+	return nil
+}
+
+func DurationUnit(durUnit string) (int, error) {
+	switch durUnit {
+	case "w", "week", "weeks":
+		return int(time.Hour * 24 * 7), nil
+	case "d", "day", "days":
+		return int(time.Hour * 24), nil
+	case "h", "hour", "hours":
+		return int(time.Hour), nil
+	case "m", "minute", "minutes":
+		return int(time.Minute), nil
+	case "s", "second", "seconds":
+		return int(time.Second), nil
+	case "ms", "millisecond", "milliseconds":
+		return int(time.Millisecond), nil
+	case "us", "µs", "μs", "microsecond", "microseconds":
+		return int(time.Microsecond), nil
+	case "ns", "nanosecond", "nanoseconds":
+		return int(time.Nanosecond), nil
+	case "":
+		return 0, fmt.Errorf("Units are required")
+	}
+
+	return 0, fmt.Errorf("Invalid unit: %q", durUnit)
+}
+
+// Duration made up of calendar units, which vary in length, and an exact duration
+type CalendarDuration struct {
+	Years    int
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+// Calendar units counted with `AddDate` rather than as exact durations so that they
+// keep the time of day across daylight saving changes
+var calendarUnits = map[string]CalendarDuration{
+	"d": {Days: 1}, "day": {Days: 1}, "days": {Days: 1},
+	"w": {Days: 7}, "wk": {Days: 7}, "wks": {Days: 7}, "week": {Days: 7}, "weeks": {Days: 7},
+	"fortnight": {Days: 14}, "fortnights": {Days: 14},
+	"mo": {Months: 1}, "mos": {Months: 1}, "month": {Months: 1}, "months": {Months: 1},
+	"y": {Years: 1}, "yr": {Years: 1}, "yrs": {Years: 1}, "year": {Years: 1}, "years": {Years: 1},
+}
+
+// Looks up a single calendar unit e.g. 'week' is 7 days
+func CalendarUnit(unit string) (CalendarDuration, bool) {
+	dur, ok := calendarUnits[unit]
+	return dur, ok
+}
+
+// Parses a duration string following the same syntax as `ParseDuration` where days,
+// weeks, months and years are calendar units
+//
+// Calendar units must be whole numbers e.g. '1mo 2w 3h30m' or 'P1M2WT3H30M'
+func ParseCalendarDuration(durStr string) (CalendarDuration, error) {
+	var total CalendarDuration
+	err := components(durStr)(durStr, func(durVal float64, unit string) error {
+		return total.Add(durVal, unit)
+	})
+
+	return total, err
+}
+
+// Adds an amount of a calendar or exact unit to the duration e.g. (3, "days")
+func (dur *CalendarDuration) Add(durVal float64, unit string) error {
+	if calendar, ok := calendarUnits[unit]; ok {
+		if durVal != float64(int(durVal)) {
+			return fmt.Errorf("Calendar units must be whole numbers: %v%s", durVal, unit)
+		}
+		dur.Years += int(durVal) * calendar.Years
+		dur.Months += int(durVal) * calendar.Months
+		dur.Days += int(durVal) * calendar.Days
+		return nil
+	}
+
+	durUnit, err := DurationUnit(unit)
+	if err != nil {
+		return err
+	}
+	dur.Duration += time.Duration(durVal * float64(durUnit))
+	return nil
+}
+
+// Duration of equal length in the opposite direction
+func (dur CalendarDuration) Negate() CalendarDuration {
+	return CalendarDuration{
+		Years:    -dur.Years,
+		Months:   -dur.Months,
+		Days:     -dur.Days,
+		Duration: -dur.Duration,
+	}
+}
+
+// Shifts a date time by the calendar units followed by the exact duration
+//
+// See `AddDate` for how the end of months and daylight saving changes are handled
+func (dur CalendarDuration) AddTo(t time.Time) time.Time {
+	return AddDate(t, dur.Years, dur.Months, dur.Days).Add(dur.Duration)
+}
+
+// Calendar difference between two date times counted in whole years, months and days
+// from `from` followed by the remaining exact duration
+//
+// Months are counted with `AddDate` so January 31st to February 28th is 1 month. When
+// `to` is before `from` every component is negative
+func CalendarDiff(from, to time.Time) CalendarDuration {
+	if to.Before(from) {
+		return CalendarDiff(to, from).Negate()
+	}
+	to = to.In(from.Location())
+
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	for months > 0 && AddDate(from, 0, months, 0).After(to) {
+		months--
+	}
+	anchor := AddDate(from, 0, months, 0)
+
+	days := int(to.Sub(anchor).Hours() / 24)
+	for days > 0 && AddDate(anchor, 0, 0, days).After(to) {
+		days--
+	}
+	for !AddDate(anchor, 0, 0, days+1).After(to) {
+		days++
+	}
+
+	return CalendarDuration{
+		Years:    months / 12,
+		Months:   months % 12,
+		Days:     days,
+		Duration: to.Sub(AddDate(anchor, 0, 0, days)),
+	}
+}
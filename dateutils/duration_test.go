@@ -0,0 +1,106 @@
+package dateutils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarDiff(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	scenarios := []struct {
+		from, to time.Time
+		want     CalendarDuration
+	}{
+		{from: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), to: time.Date(2026, 3, 4, 4, 5, 6, 0, time.UTC), want: CalendarDuration{Years: 1, Months: 2, Days: 3, Duration: 4*time.Hour + 5*time.Minute + 6*time.Second}},
+		{from: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), to: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), want: CalendarDuration{Months: 1}},
+		{from: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), to: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), want: CalendarDuration{Years: 1}},
+		{from: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), to: time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC), want: CalendarDuration{Duration: 18 * time.Hour}},
+		{from: time.Date(2026, 3, 4, 4, 5, 6, 0, time.UTC), to: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), want: CalendarDuration{Years: -1, Months: -2, Days: -3, Duration: -(4*time.Hour + 5*time.Minute + 6*time.Second)}},
+		{from: time.Date(2025, 5, 9, 0, 0, 0, 0, time.UTC), to: time.Date(2025, 5, 9, 0, 0, 0, 0, time.UTC), want: CalendarDuration{}},
+		// A day is 23 hours when the clocks go forward
+		{from: time.Date(2025, 3, 29, 12, 0, 0, 0, london), to: time.Date(2025, 3, 30, 12, 0, 0, 0, london), want: CalendarDuration{Days: 1}},
+		{from: time.Date(2025, 10, 25, 12, 0, 0, 0, london), to: time.Date(2025, 10, 26, 12, 0, 0, 0, london), want: CalendarDuration{Days: 1}},
+		// The end is compared on the wall clock of the start
+		{from: time.Date(2025, 5, 9, 0, 0, 0, 0, london), to: time.Date(2025, 5, 9, 23, 0, 0, 0, time.UTC), want: CalendarDuration{Days: 1}},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := CalendarDiff(testCase.from, testCase.to); got != testCase.want {
+				t.Errorf("Fail diffing %s and %s\nGot:  %+v\nwant: %+v", testCase.from, testCase.to, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	scenarios := []struct {
+		input string
+		want  time.Duration
+	}{
+		{input: "1h30m", want: 90 * time.Minute},
+		{input: "1d 2h", want: 26 * time.Hour},
+		{input: "1.5s", want: 1500 * time.Millisecond},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "3µs", want: 3 * time.Microsecond},
+		{input: "P1DT2H30M", want: 26*time.Hour + 30*time.Minute},
+		{input: "PT1.5S", want: 1500 * time.Millisecond},
+		{input: "PT0,5H", want: 30 * time.Minute},
+		{input: "P1W", want: 7 * 24 * time.Hour},
+		{input: "pt90m", want: 90 * time.Minute},
+		{input: "-1h30m", want: -90 * time.Minute},
+		{input: "1h-5m", want: 55 * time.Minute},
+		{input: "-1h+5m", want: -55 * time.Minute},
+		{input: "+2h", want: 2 * time.Hour},
+		{input: "- 1d 2h", want: -26 * time.Hour},
+		{input: "-PT1H30M", want: -90 * time.Minute},
+		{input: "+P1D", want: 24 * time.Hour},
+		{input: "-P1DT0.5H", want: -24*time.Hour - 30*time.Minute},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseDuration(testCase.input)
+			if err != nil {
+				t.Errorf("Failed to parse duration '%s'\n%s", testCase.input, err)
+				return
+			}
+			if time.Duration(got) != testCase.want {
+				t.Errorf("Fail parsing '%s'\nGot:  %s\nwant: %s", testCase.input, time.Duration(got), testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseDurationInvalid(t *testing.T) {
+	scenarios := []string{
+		"P",
+		"PT",
+		"P1H",
+		"PT1D",
+		"P1M",
+		"P1Y2D",
+		"1mo",
+		"1",
+		"1 h",
+		"1x",
+		"--1h",
+		"+-1h",
+		"1h-",
+		"-",
+		"--PT1H",
+		"PT-1H",
+	}
+
+	for _, input := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseDuration(input)
+			if err == nil {
+				t.Errorf("Expected '%s' to fail parsing\nGot: %s", input, time.Duration(got))
+			}
+		})
+	}
+}
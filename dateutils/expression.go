@@ -0,0 +1,221 @@
+package dateutils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Value of an expression which is either a duration in nanoseconds or a plain number
+// e.g. the 3 in '1d / 3'
+type quantity struct {
+	value    float64
+	duration bool
+}
+
+// Evaluates an arithmetic expression of durations such as '2h + 45m - 30s', '1d / 3',
+// '90m * 4' or '(1h + 20m) % 15m' returning the result in nanoseconds
+//
+// Durations follow the syntax of `ParseDuration` where neighbouring durations
+// separated by spaces are summed e.g. '1h 30m * 2' is 3 hours. Dividing a duration
+// by another produces a plain number which is reported by `isDuration` being false
+func EvaluateDuration(expr string) (value float64, isDuration bool, err error) {
+	tokens, err := tokeniseExpression(expr)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(tokens) == 0 {
+		return 0, false, fmt.Errorf("Empty duration expression")
+	}
+
+	parser := expressionParser{tokens: tokens}
+	result, err := parser.expression()
+	if err != nil {
+		return 0, false, err
+	}
+	if parser.pos < len(parser.tokens) {
+		return 0, false, fmt.Errorf("Unexpected %q in duration expression", parser.tokens[parser.pos])
+	}
+
+	return result.value, result.duration, nil
+}
+
+// Characters that can make up a duration or number, including the comma of ISO 8601
+// fractions and the '_' digit separator
+func isLiteralChar(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char) || char == '.' || char == ',' || char == '_'
+}
+
+// Splits an expression into operators, parentheses and literals where literals only
+// separated by spaces are joined e.g. '1h 30m + 5m' is ["1h 30m", "+", "5m"]
+func tokeniseExpression(expr string) ([]string, error) {
+	tokens := []string{}
+	lastLiteral := false
+
+	runes := []rune(expr)
+	for idx := 0; idx < len(runes); {
+		char := runes[idx]
+		switch {
+		case unicode.IsSpace(char):
+			idx++
+		case strings.ContainsRune("+-*/%()", char):
+			tokens = append(tokens, string(char))
+			lastLiteral = false
+			idx++
+		case isLiteralChar(char):
+			end := idx
+			for end < len(runes) && isLiteralChar(runes[end]) {
+				end++
+			}
+			literal := string(runes[idx:end])
+			if lastLiteral {
+				tokens[len(tokens)-1] += " " + literal
+			} else {
+				tokens = append(tokens, literal)
+			}
+			lastLiteral = true
+			idx = end
+		default:
+			return nil, fmt.Errorf("Invalid character: %q", char)
+		}
+	}
+
+	return tokens, nil
+}
+
+// Recursive descent parser over the tokens of an expression with the usual
+// precedence: parentheses, then unary signs, then '*', '/' and '%', then '+' and '-'
+type expressionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (parser *expressionParser) peek() string {
+	if parser.pos < len(parser.tokens) {
+		return parser.tokens[parser.pos]
+	}
+	return ""
+}
+
+func (parser *expressionParser) expression() (quantity, error) {
+	left, err := parser.term()
+	if err != nil {
+		return left, err
+	}
+
+	for op := parser.peek(); op == "+" || op == "-"; op = parser.peek() {
+		parser.pos++
+		right, err := parser.term()
+		if err != nil {
+			return left, err
+		}
+		if left.duration != right.duration {
+			return left, fmt.Errorf("Cannot combine a number and a duration with %q", op)
+		}
+
+		if op == "+" {
+			left.value += right.value
+		} else {
+			left.value -= right.value
+		}
+	}
+
+	return left, nil
+}
+
+func (parser *expressionParser) term() (quantity, error) {
+	left, err := parser.unary()
+	if err != nil {
+		return left, err
+	}
+
+	for op := parser.peek(); op == "*" || op == "/" || op == "%"; op = parser.peek() {
+		parser.pos++
+		right, err := parser.unary()
+		if err != nil {
+			return left, err
+		}
+
+		switch op {
+		case "*":
+			if left.duration && right.duration {
+				return left, fmt.Errorf("Cannot multiply two durations")
+			}
+			left = quantity{left.value * right.value, left.duration || right.duration}
+		case "/":
+			if right.value == 0 {
+				return left, fmt.Errorf("Cannot divide by zero")
+			}
+			if right.duration && !left.duration {
+				return left, fmt.Errorf("Cannot divide a number by a duration")
+			}
+			// Units cancel out when dividing a duration by another
+			left = quantity{left.value / right.value, left.duration && !right.duration}
+		case "%":
+			if right.value == 0 {
+				return left, fmt.Errorf("Cannot divide by zero")
+			}
+			if left.duration != right.duration {
+				return left, fmt.Errorf("Cannot take the remainder of a number and a duration")
+			}
+			left.value = math.Mod(left.value, right.value)
+		}
+	}
+
+	return left, nil
+}
+
+func (parser *expressionParser) unary() (quantity, error) {
+	switch parser.peek() {
+	case "-":
+		parser.pos++
+		value, err := parser.unary()
+		value.value = -value.value
+		return value, err
+	case "+":
+		parser.pos++
+		return parser.unary()
+	}
+
+	return parser.primary()
+}
+
+func (parser *expressionParser) primary() (quantity, error) {
+	token := parser.peek()
+	switch token {
+	case "":
+		return quantity{}, fmt.Errorf("Unexpected end of duration expression")
+	case "(":
+		parser.pos++
+		value, err := parser.expression()
+		if err != nil {
+			return value, err
+		}
+		if parser.peek() != ")" {
+			return value, fmt.Errorf("Expected ')' in duration expression")
+		}
+		parser.pos++
+		return value, nil
+	case ")", "*", "/", "%":
+		return quantity{}, fmt.Errorf("Unexpected %q in duration expression", token)
+	}
+	parser.pos++
+
+	// Literals without units are plain numbers
+	number := strings.ReplaceAll(token, "_", "")
+	if strings.Trim(number, "0123456789.") == "" {
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return quantity{}, fmt.Errorf("Invalid number %q", token)
+		}
+		return quantity{value: value}, nil
+	}
+
+	value, err := ParseDuration(token)
+	if err != nil {
+		return quantity{}, err
+	}
+	return quantity{value: value, duration: true}, nil
+}
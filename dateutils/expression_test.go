@@ -0,0 +1,75 @@
+package dateutils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateDuration(t *testing.T) {
+	scenarios := []struct {
+		expr       string
+		want       float64
+		isDuration bool
+	}{
+		{expr: "2h + 45m - 30s", want: float64(2*time.Hour + 45*time.Minute - 30*time.Second), isDuration: true},
+		{expr: "1d / 3", want: float64(8 * time.Hour), isDuration: true},
+		{expr: "90m * 4", want: float64(6 * time.Hour), isDuration: true},
+		{expr: "4 * 90m", want: float64(6 * time.Hour), isDuration: true},
+		{expr: "(1h + 20m) % 15m", want: float64(5 * time.Minute), isDuration: true},
+		{expr: "1h 30m * 2", want: float64(3 * time.Hour), isDuration: true},
+		{expr: "1h + 30m * 2", want: float64(2 * time.Hour), isDuration: true},
+		{expr: "(1h + 30m) * 2", want: float64(3 * time.Hour), isDuration: true},
+		{expr: "1h - 30m - 15m", want: float64(15 * time.Minute), isDuration: true},
+		{expr: "-1h + 2h", want: float64(time.Hour), isDuration: true},
+		{expr: "-(1h + 2h)", want: float64(-3 * time.Hour), isDuration: true},
+		{expr: "PT1H + 30m", want: float64(90 * time.Minute), isDuration: true},
+		{expr: "1d / 1h", want: 24},
+		{expr: "1_000 * 1ms", want: float64(time.Second), isDuration: true},
+		{expr: "2 * 3 + 4", want: 10},
+		{expr: "8 / 2 / 2", want: 2},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.expr, func(t *testing.T) {
+			t.Parallel()
+			got, isDuration, err := EvaluateDuration(testCase.expr)
+			if err != nil {
+				t.Errorf("Failed to evaluate '%s'\n%s", testCase.expr, err)
+				return
+			}
+			if got != testCase.want || isDuration != testCase.isDuration {
+				t.Errorf("Fail evaluating '%s'\nGot:  %v (duration %t)\nwant: %v (duration %t)", testCase.expr, got, isDuration, testCase.want, testCase.isDuration)
+			}
+		})
+	}
+}
+
+func TestEvaluateDurationInvalid(t *testing.T) {
+	scenarios := []string{
+		"",
+		"1h / 0",
+		"1h / 0s",
+		"1h % 0",
+		"1h + 1",
+		"1 - 1h",
+		"1h * 1h",
+		"2 / 1h",
+		"1h % 2",
+		"(1h + 2h",
+		"1h + 2h)",
+		"1h +",
+		"* 1h",
+		"1h ^ 2",
+		"1x + 1h",
+	}
+
+	for _, expr := range scenarios {
+		t.Run(expr, func(t *testing.T) {
+			t.Parallel()
+			got, _, err := EvaluateDuration(expr)
+			if err == nil {
+				t.Errorf("Expected '%s' to fail evaluating\nGot: %v", expr, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package localiser
+
+import (
+	"github.com/go-playground/locales"
+)
+
+// Phrases describing a date time relative to another in a language where each
+// phrase is a format string for `fmt.Sprintf`
+type RelativePhrases struct {
+	// Wraps an amount of time in the future e.g. "in %s"
+	Future string
+	// Wraps an amount of time in the past e.g. "%s ago"
+	Past string
+	// Less than a minute
+	FewSeconds string
+	// A single unit of time e.g. "an hour" keyed by the singular English name
+	Single map[string]string
+
+	// Calendar phrases taking a time of day e.g. "today at %s"
+	Today     string
+	Yesterday string
+	Tomorrow  string
+	// Calendar phrases taking a weekday and a time of day e.g. "last %s at %s"
+	LastWeek string
+	NextWeek string
+}
+
+var relativeTranslations = map[string]RelativePhrases{
+	"en": {
+		Future:     "in %s",
+		Past:       "%s ago",
+		FewSeconds: "a few seconds",
+		Single: map[string]string{
+			"minute": "a minute",
+			"hour":   "an hour",
+			"day":    "a day",
+			"month":  "a month",
+			"year":   "a year",
+		},
+		Today:     "today at %s",
+		Yesterday: "yesterday at %s",
+		Tomorrow:  "tomorrow at %s",
+		LastWeek:  "last %s at %s",
+		NextWeek:  "%s at %s",
+	},
+	"fr": {
+		Future:     "dans %s",
+		Past:       "il y a %s",
+		FewSeconds: "quelques secondes",
+		Single: map[string]string{
+			"minute": "une minute",
+			"hour":   "une heure",
+			"day":    "un jour",
+			"month":  "un mois",
+			"year":   "un an",
+		},
+		Today:     "aujourd’hui à %s",
+		Yesterday: "hier à %s",
+		Tomorrow:  "demain à %s",
+		LastWeek:  "%s dernier à %s",
+		NextWeek:  "%s à %s",
+	},
+	"es": {
+		Future:     "en %s",
+		Past:       "hace %s",
+		FewSeconds: "unos segundos",
+		Single: map[string]string{
+			"minute": "un minuto",
+			"hour":   "una hora",
+			"day":    "un día",
+			"month":  "un mes",
+			"year":   "un año",
+		},
+		Today:     "hoy a las %s",
+		Yesterday: "ayer a las %s",
+		Tomorrow:  "mañana a las %s",
+		LastWeek:  "el %s pasado a las %s",
+		NextWeek:  "%s a las %s",
+	},
+}
+
+// Phrases for describing relative date times in the language of the locale falling
+// back to English for unsupported languages
+func Relative(locale locales.Translator) RelativePhrases {
+	phrases, ok := relativeTranslations[language(locale)]
+	if !ok {
+		return relativeTranslations["en"]
+	}
+	return phrases
+}
@@ -0,0 +1,78 @@
+package localiser
+
+import (
+	"strings"
+
+	"github.com/go-playground/locales"
+)
+
+// Names of a unit of time keyed by plural category
+type unitNames map[locales.PluralRule]string
+
+// Names of units of time for each supported language, the go-playground locales only
+// provide plural rules so the names themselves are kept here
+var unitTranslations = map[string]map[string]unitNames{
+	"en": {
+		"year":        {locales.PluralRuleOne: "year", locales.PluralRuleOther: "years"},
+		"month":       {locales.PluralRuleOne: "month", locales.PluralRuleOther: "months"},
+		"week":        {locales.PluralRuleOne: "week", locales.PluralRuleOther: "weeks"},
+		"day":         {locales.PluralRuleOne: "day", locales.PluralRuleOther: "days"},
+		"hour":        {locales.PluralRuleOne: "hour", locales.PluralRuleOther: "hours"},
+		"minute":      {locales.PluralRuleOne: "minute", locales.PluralRuleOther: "minutes"},
+		"second":      {locales.PluralRuleOne: "second", locales.PluralRuleOther: "seconds"},
+		"millisecond": {locales.PluralRuleOne: "millisecond", locales.PluralRuleOther: "milliseconds"},
+		"microsecond": {locales.PluralRuleOne: "microsecond", locales.PluralRuleOther: "microseconds"},
+		"nanosecond":  {locales.PluralRuleOne: "nanosecond", locales.PluralRuleOther: "nanoseconds"},
+	},
+	"fr": {
+		"year":        {locales.PluralRuleOne: "an", locales.PluralRuleOther: "ans"},
+		"month":       {locales.PluralRuleOne: "mois", locales.PluralRuleOther: "mois"},
+		"week":        {locales.PluralRuleOne: "semaine", locales.PluralRuleOther: "semaines"},
+		"day":         {locales.PluralRuleOne: "jour", locales.PluralRuleOther: "jours"},
+		"hour":        {locales.PluralRuleOne: "heure", locales.PluralRuleOther: "heures"},
+		"minute":      {locales.PluralRuleOne: "minute", locales.PluralRuleOther: "minutes"},
+		"second":      {locales.PluralRuleOne: "seconde", locales.PluralRuleOther: "secondes"},
+		"millisecond": {locales.PluralRuleOne: "milliseconde", locales.PluralRuleOther: "millisecondes"},
+		"microsecond": {locales.PluralRuleOne: "microseconde", locales.PluralRuleOther: "microsecondes"},
+		"nanosecond":  {locales.PluralRuleOne: "nanoseconde", locales.PluralRuleOther: "nanosecondes"},
+	},
+	"es": {
+		"year":        {locales.PluralRuleOne: "año", locales.PluralRuleOther: "años"},
+		"month":       {locales.PluralRuleOne: "mes", locales.PluralRuleOther: "meses"},
+		"week":        {locales.PluralRuleOne: "semana", locales.PluralRuleOther: "semanas"},
+		"day":         {locales.PluralRuleOne: "día", locales.PluralRuleOther: "días"},
+		"hour":        {locales.PluralRuleOne: "hora", locales.PluralRuleOther: "horas"},
+		"minute":      {locales.PluralRuleOne: "minuto", locales.PluralRuleOther: "minutos"},
+		"second":      {locales.PluralRuleOne: "segundo", locales.PluralRuleOther: "segundos"},
+		"millisecond": {locales.PluralRuleOne: "milisegundo", locales.PluralRuleOther: "milisegundos"},
+		"microsecond": {locales.PluralRuleOne: "microsegundo", locales.PluralRuleOther: "microsegundos"},
+		"nanosecond":  {locales.PluralRuleOne: "nanosegundo", locales.PluralRuleOther: "nanosegundos"},
+	},
+}
+
+// Language of a locale e.g. "fr" for "fr_FR"
+func language(locale locales.Translator) string {
+	lang, _, _ := strings.Cut(locale.Locale(), "_")
+	return lang
+}
+
+// Formats an amount of a unit of time in the language of the locale using its plural
+// rules e.g. "2 hours" or "1 heure"
+//
+// `unit` is the singular English name of the unit from "year" down to "nanosecond"
+// and `v` is the number of decimal places to show. Unsupported languages fall back
+// to English
+func FormatUnit(locale locales.Translator, unit string, num float64, v uint64) string {
+	translations, ok := unitTranslations[language(locale)]
+	if !ok {
+		translations = unitTranslations["en"]
+	}
+
+	names := translations[unit]
+	name, ok := names[locale.CardinalPluralRule(num, v)]
+	if !ok {
+		name = names[locales.PluralRuleOther]
+	}
+
+	return locale.FmtNumber(num, v) + " " + name
+}
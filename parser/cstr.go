@@ -18,17 +18,39 @@ import "C"
 
 import (
 	"fmt"
+	"math"
 	"time"
 	"unsafe"
 
 	"github.com/go-playground/locales"
 )
 
+// Sentinel for `tm_gmtoff` used to detect whether `strptime` parsed a time zone offset
+// as glibc only sets the field for '%z' and '%s'
+const unsetGmtOffset = math.MinInt32
+
+// Converts a C tm struct filled by `strptime` into a Go based time.Time
+//
+// The parsed offset is used for the location when one was read otherwise `location`
+// is used
 func tmToTime(tm *C.struct_tm, location *time.Location) time.Time {
+	if tm.tm_gmtoff != unsetGmtOffset {
+		offset := int(tm.tm_gmtoff)
+		zone := ""
+		if tm.tm_zone != nil {
+			zone = C.GoString(tm.tm_zone)
+		}
+		location = time.FixedZone(zone, offset)
+		if offset == 0 && (zone == "" || zone == "UTC" || zone == "GMT") {
+			location = time.UTC
+		}
+	}
+
 	return time.Date(
-		int(tm.tm_year),
+		// Years since 1900
+		int(tm.tm_year)+1900,
 		time.Month(tm.tm_mon+1),
-		int(tm.tm_mday+1),
+		int(tm.tm_mday),
 		int(tm.tm_hour),
 		int(tm.tm_min),
 		int(tm.tm_sec),
@@ -57,8 +79,8 @@ func timeToTm(dt *time.Time) C.struct_tm {
 		tm_hour: C.int(dt.Hour()),
 		tm_min:  C.int(dt.Minute()),
 		tm_sec:  C.int(dt.Second()),
-		// Offset in minutes
-		tm_gmtoff: C.long(offset / 60),
+		// Offset in seconds
+		tm_gmtoff: C.long(offset),
 		tm_isdst:  C.int(dst),
 		tm_yday:   C.int(dt.YearDay()),
 		tm_zone:   C.CString(zone),
@@ -71,9 +93,7 @@ func timeToTm(dt *time.Time) C.struct_tm {
 var CStr = DateHandlerTokenWrapper{
 	format: func(dt time.Time, locale locales.Translator, formatStr string) string {
 		format := C.CString(formatStr)
-		result := C.CString("")
 		defer C.free(unsafe.Pointer(format))
-		defer C.free(unsafe.Pointer(result))
 
 		tm := timeToTm(&dt)
 		defer C.free(unsafe.Pointer(tm.tm_zone))
@@ -87,23 +107,47 @@ var CStr = DateHandlerTokenWrapper{
 		// Nearest multiple of 8 = 16
 		const MAX_CHAR_PRINT = 16
 		bufferSize := len(formatStr) * MAX_CHAR_PRINT
+		result := (*C.char)(C.malloc(C.size_t(bufferSize * C.sizeof_char)))
+		defer C.free(unsafe.Pointer(result))
 		C.strftime(result, C.size_t(bufferSize*C.sizeof_char), format, &tm)
 
 		return C.GoString(result)
 	},
-	parse: func(input, format string) (time.Time, error) {
+	parse: func(input, format string, locale locales.Translator) (time.Time, error) {
+		// Fields not set by the format default to midnight of the unix epoch
 		tm := C.struct_tm{
-			// NOTE: this needs the full year not just the years since 1900
-			// Unsure why in this particular case and not when formatting
-			tm_year: C.int(time.Unix(0, 0).Year()),
+			tm_year:   70,
+			tm_mday:   1,
+			tm_gmtoff: unsetGmtOffset,
 		}
 
 		cInput, cFormat := C.CString(input), C.CString(format)
 		defer C.free(unsafe.Pointer(cInput))
 		defer C.free(unsafe.Pointer(cFormat))
 
-		C.strptime(cInput, cFormat, &tm)
+		localeStr := C.CString(fmt.Sprintf("%s.UTF-8", locale.Locale()))
+		defer C.free(unsafe.Pointer(localeStr))
+		C.setlocale(C.LC_TIME, localeStr)
+
+		// Points to the first character not consumed or NULL when the input does not
+		// match the format
+		end := C.strptime(cInput, cFormat, &tm)
+		if end == nil {
+			return time.Time{}, &ParseError{Input: input, Layout: format, Offset: -1, Err: fmt.Errorf("Input does not match the layout")}
+		}
+		if offset := int(uintptr(unsafe.Pointer(end)) - uintptr(unsafe.Pointer(cInput))); offset < len(input) {
+			return time.Time{}, &ParseError{Input: input, Layout: format, Offset: offset, Err: fmt.Errorf("Unexpected trailing input %q", input[offset:])}
+		}
+
 		dt := tmToTime(&tm, time.Local)
+		if dt.Day() != int(tm.tm_mday) {
+			return time.Time{}, &ParseError{
+				Input:  input,
+				Layout: format,
+				Offset: len(input),
+				Err:    fmt.Errorf("Day %d is out of range for %s %d", tm.tm_mday, time.Month(tm.tm_mon+1), tm.tm_year+1900),
+			}
+		}
 		return dt, nil
 	},
 	prefix:   '%',
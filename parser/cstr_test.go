@@ -0,0 +1,81 @@
+//go:build cgo
+
+package parser
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/locales/en_GB"
+)
+
+func TestFormatCStr(t *testing.T) {
+	kolkata := time.FixedZone("IST", 19800)
+	stJohns := time.FixedZone("NST", -12600)
+	scenarios := []struct {
+		dt     time.Time
+		format string
+		want   string
+	}{
+		{dt: time.Date(1997, 1, 4, 15, 9, 2, 0, kolkata), format: "%Y-%m-%d %H:%M:%S %z", want: "1997-01-04 15:09:02 +0530"},
+		{dt: time.Date(1997, 1, 4, 15, 9, 2, 0, stJohns), format: "%H:%M %z %Z", want: "15:09 -0330 NST"},
+		{dt: time.Date(1997, 1, 4, 15, 9, 2, 0, time.UTC), format: "%z", want: "+0000"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			if got := CStr.Format(testCase.dt, en_GB.New(), &testCase.format); got != testCase.want {
+				t.Errorf("Fail formatting %q\nGot:  %s\nwant: %s", testCase.format, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseCStr(t *testing.T) {
+	scenarios := []testCase{
+		{input: "04/01/97", format: "%d/%m/%y", want: time.Date(1997, 1, 4, 0, 0, 0, 0, time.Local)},
+		{input: "15:09", format: "%H:%M", want: time.Date(1970, 1, 1, 15, 9, 0, 0, time.Local)},
+		{input: "1997-01-04 15:09:02 +0530", format: "%Y-%m-%d %H:%M:%S %z", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.FixedZone("", 19800))},
+		{input: "1997-01-04 15:09:02 Z", format: "%Y-%m-%d %H:%M:%S %z", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.UTC)},
+		{input: "852390542", format: "%s", want: time.Unix(852390542, 0)},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			got, err := CStr.Parse(testCase.input, testCase.format, en_GB.New())
+			if err != nil {
+				t.Errorf("Failed to parse: '%s' with format '%s'\n%s", testCase.input, testCase.format, err)
+				return
+			}
+			if got.Compare(testCase.want) != 0 {
+				t.Errorf("Fail\nGot:  %s\nwant: %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseCStrInvalid(t *testing.T) {
+	scenarios := []struct {
+		testCase
+		offset int
+	}{
+		{testCase: testCase{input: "nope", format: "%Y-%m-%d"}, offset: -1},
+		{testCase: testCase{input: "1997-01-04 trailing", format: "%Y-%m-%d"}, offset: 10},
+		{testCase: testCase{input: "1997-02-31", format: "%Y-%m-%d"}, offset: 10},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run("", func(t *testing.T) {
+			got, err := CStr.Parse(scenario.input, scenario.format, en_GB.New())
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Errorf("Expected '%s' to fail parsing with format '%s'\nGot: %s, %v", scenario.input, scenario.format, got, err)
+				return
+			}
+			if parseErr.Offset != scenario.offset {
+				t.Errorf("Expected failure at position %d but got %d", scenario.offset, parseErr.Offset)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/locales"
+)
+
+// Known date time format that can be recognised without a layout
+type detectFormat struct {
+	// Name reported when an input matches the format
+	name  string
+	parse func(input string, locale locales.Translator, location *time.Location) (time.Time, error)
+}
+
+// Creates a detectable format from a Go layout where inputs without a time zone are
+// interpreted in the local time zone
+func goLayoutFormat(name, layout string) detectFormat {
+	return detectFormat{
+		name: name,
+		parse: func(input string, locale locales.Translator, location *time.Location) (time.Time, error) {
+			dt, err := time.ParseInLocation(layout, input, time.Local)
+			if err != nil {
+				return dt, err
+			}
+			return dt, checkWeekday(dt, layout, input)
+		},
+	}
+}
+
+// Go ignores the weekday when parsing so layouts starting with one are checked against
+// the parsed date e.g. 'Tue, 07 Jan 2024' is rejected as January 7th 2024 was a Sunday
+func checkWeekday(dt time.Time, layout, input string) error {
+	for _, weekdayLayout := range []string{"Monday", "Mon"} {
+		if !strings.HasPrefix(layout, weekdayLayout) {
+			continue
+		}
+		weekday := dt.Format(weekdayLayout)
+		if len(input) < len(weekday) || !strings.EqualFold(input[:len(weekday)], weekday) {
+			return fmt.Errorf("Weekday of %q does not match the parsed date %s", input, dt.Format(time.DateOnly))
+		}
+		return nil
+	}
+	return nil
+}
+
+// Creates a detectable format from a Go layout without a year where the current year
+// is assumed e.g. syslog timestamps
+func goLayoutFormatNoYear(name, layout string) detectFormat {
+	return detectFormat{
+		name: name,
+		parse: func(input string, locale locales.Translator, location *time.Location) (time.Time, error) {
+			dt, err := time.ParseInLocation(layout, input, time.Local)
+			if err != nil {
+				return dt, err
+			}
+			return inYear(dt, time.Now().Year())
+		},
+	}
+}
+
+// Moves a date time parsed without a year into the provided year keeping the wall
+// clock, failing for February 29th outside of leap years
+func inYear(dt time.Time, year int) (time.Time, error) {
+	moved := time.Date(year, dt.Month(), dt.Day(), dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), dt.Location())
+	if moved.Day() != dt.Day() {
+		return time.Time{}, fmt.Errorf("Day %d of %s does not exist in %d", dt.Day(), dt.Month(), year)
+	}
+	return moved, nil
+}
+
+// Creates a detectable format from a Go layout with only a time where the current
+// date is assumed
+func goLayoutFormatNoDate(name, layout string) detectFormat {
+	return detectFormat{
+		name: name,
+		parse: func(input string, locale locales.Translator, location *time.Location) (time.Time, error) {
+			dt, err := time.ParseInLocation(layout, input, time.Local)
+			if err != nil {
+				return dt, err
+			}
+			year, month, day := time.Now().Date()
+			return time.Date(year, month, day, dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), time.Local), nil
+		},
+	}
+}
+
+// Creates a detectable format from a strptime layout
+func strptimeFormat(name, layout string) detectFormat {
+	return detectFormat{
+		name: name,
+		parse: func(input string, locale locales.Translator, location *time.Location) (time.Time, error) {
+			return GoStrptime.Parse(input, layout, locale)
+		},
+	}
+}
+
+var unixPattern = regexp.MustCompile(`^-?(\d+)(?:\.\d{1,9})?$`)
+
+// Creates a detectable format for unix timestamps in multiples of `unit` where the
+// integer part has between `minDigits` and `maxDigits` digits
+func unixFormat(name string, unit time.Duration, minDigits, maxDigits int) detectFormat {
+	return detectFormat{
+		name: name,
+		parse: func(input string, locale locales.Translator, location *time.Location) (time.Time, error) {
+			match := unixPattern.FindStringSubmatch(input)
+			if match == nil || len(match[1]) < minDigits || len(match[1]) > maxDigits {
+				return time.Time{}, fmt.Errorf("Input is not a unix timestamp in %s", name)
+			}
+
+			state := parseState{}
+			if _, err := unixParser(unit)(&state, input); err != nil {
+				return time.Time{}, err
+			}
+			return state.unix.In(time.Local), nil
+		},
+	}
+}
+
+// Formats tried in order when detecting the format of an input
+//
+// Go layouts accept fractional seconds after the seconds whether or not the layout
+// includes them, separated by either a period or a comma as in Python's logging
+//
+// More specific formats come first so ambiguous inputs resolve to the most likely
+// format e.g. '20240107' is an ISO 8601 basic date rather than a unix timestamp and
+// unix timestamps are interpreted by their number of digits. Unix seconds need at
+// least 9 digits, from March 1973, so a year such as '2025' is not a timestamp
+var detectFormats = []detectFormat{
+	goLayoutFormat("ISO 8601 basic date", "20060102"),
+	goLayoutFormat("ISO 8601 basic date time", "20060102T150405"),
+	goLayoutFormat("ISO 8601 basic date time", "20060102T150405Z0700"),
+	goLayoutFormat("ISO 8601 basic date time", "20060102T150405.999999999"),
+	goLayoutFormat("ISO 8601 basic date time", "20060102T150405.999999999Z0700"),
+	goLayoutFormat("ISO 8601 basic date time", "20060102T1504"),
+	goLayoutFormat("ISO 8601 basic date time", "20060102T1504Z0700"),
+	goLayoutFormat("ISO 8601 basic date time", "20060102150405"),
+	unixFormat("unix seconds", time.Second, 9, 11),
+	unixFormat("unix milliseconds", time.Millisecond, 12, 14),
+	unixFormat("unix microseconds", time.Microsecond, 15, 17),
+	unixFormat("unix nanoseconds", time.Nanosecond, 18, 19),
+	goLayoutFormat("RFC 3339", time.RFC3339Nano),
+	goLayoutFormat("RFC 3339", "2006-01-02 15:04:05.999999999Z07:00"),
+	goLayoutFormat("ISO 8601", "2006-01-02T15:04:05.999999999Z0700"),
+	goLayoutFormat("ISO 8601", "2006-01-02T15:04:05.999999999Z07"),
+	goLayoutFormat("ISO 8601", "2006-01-02T15:04:05.999999999"),
+	goLayoutFormat("ISO 8601", "2006-01-02T15:04Z07:00"),
+	goLayoutFormat("ISO 8601", "2006-01-02T15:04"),
+	goLayoutFormat("ISO 8601", "2006-01-02T15"),
+	goLayoutFormat("ISO 8601 date", time.DateOnly),
+	goLayoutFormat("ISO 8601 month", "2006-01"),
+	goLayoutFormat("ISO 8601 year", "2006"),
+	strptimeFormat("ISO 8601 week date", "%G-W%V-%u"),
+	strptimeFormat("ISO 8601 week date", "%GW%V%u"),
+	strptimeFormat("ISO 8601 week", "%G-W%V"),
+	strptimeFormat("ISO 8601 ordinal date", "%Y-%j"),
+	goLayoutFormat("Go time.String()", "2006-01-02 15:04:05.999999999 -0700 MST"),
+	// Unnamed fixed zones repeat the offset in place of the abbreviation
+	goLayoutFormat("Go time.String()", "2006-01-02 15:04:05.999999999 -0700 -0700"),
+	goLayoutFormat("date time", "2006-01-02 15:04:05.999999999 -0700"),
+	goLayoutFormat("date time", "2006-01-02 15:04:05.999999999 MST"),
+	goLayoutFormat("date time", "2006-01-02 15:04:05.999999999"),
+	goLayoutFormat("date time", "2006-01-02 15:04"),
+	goLayoutFormat("Go log", "2006/01/02 15:04:05.999999"),
+	goLayoutFormat("RFC 1123", time.RFC1123),
+	goLayoutFormat("RFC 1123", time.RFC1123Z),
+	goLayoutFormat("RFC 2822", "Mon, 2 Jan 2006 15:04:05 -0700"),
+	goLayoutFormat("RFC 2822", "Mon, 2 Jan 2006 15:04:05 MST"),
+	goLayoutFormat("RFC 2822", "2 Jan 2006 15:04:05 -0700"),
+	goLayoutFormat("RFC 2822", "Mon, 2 Jan 2006 15:04 -0700"),
+	goLayoutFormat("RFC 850", time.RFC850),
+	goLayoutFormat("RFC 822", time.RFC822),
+	goLayoutFormat("RFC 822", time.RFC822Z),
+	goLayoutFormat("ANSI C", time.ANSIC),
+	goLayoutFormat("Unix date", time.UnixDate),
+	goLayoutFormat("Ruby date", time.RubyDate),
+	goLayoutFormat("Common Log Format", "02/Jan/2006:15:04:05 -0700"),
+	goLayoutFormatNoYear("syslog", time.Stamp),
+	goLayoutFormat("JavaScript Date.toString()", "Mon Jan 02 2006 15:04:05 GMT-0700"),
+	goLayoutFormatNoDate("12 hour time", time.Kitchen),
+	goLayoutFormatNoDate("time", time.TimeOnly),
+	{
+		name: "natural language",
+		parse: func(input string, locale locales.Translator, location *time.Location) (time.Time, error) {
+			return ParseNatural(input, time.Now().In(location))
+		},
+	},
+}
+
+// Parses an input by trying each known format in turn returning the parsed date time
+// and the name of the format that matched
+//
+// Natural language is read relative to the current time in the location
+func Detect(input string, locale locales.Translator, location *time.Location) (time.Time, string, error) {
+	input = strings.TrimSpace(input)
+
+	// Output of `time.Time.String()` may include a monotonic clock reading
+	if idx := strings.Index(input, " m="); idx != -1 {
+		input = input[:idx]
+	}
+	// JavaScript's `Date.toString()` ends with the time zone name in brackets
+	if strings.HasSuffix(input, ")") {
+		if idx := strings.LastIndex(input, " ("); idx != -1 {
+			input = input[:idx]
+		}
+	}
+
+	for _, format := range detectFormats {
+		if dt, err := format.parse(input, locale, location); err == nil {
+			return dt, format.name, nil
+		}
+	}
+
+	return time.Time{}, "", fmt.Errorf("Unable to detect the format of %q", input)
+}
+
+// Lists the names of every format that can be detected in order of priority
+func DetectFormatNames() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, format := range detectFormats {
+		if !seen[format.name] {
+			seen[format.name] = true
+			names = append(names, format.name)
+		}
+	}
+	return names
+}
+
+// Shapes of date times commonly found within logs and other text, tried in order at
+// each position so longer shapes come before those they start with
+var timestampPatterns = []string{
+	// RFC 3339, ISO 8601 and Go time.String() e.g. '2025-05-09 15:00:40.123 +0100 BST'
+	`\d{4}-\d{2}-\d{2}(?:[T ]\d{2}:\d{2}(?::\d{2}(?:[.,]\d{1,9})?)?(?:Z| ?[+-]\d{2}(?::?\d{2})?(?: [A-Z]{3,5}\b)?)?)?`,
+	// Common Log Format e.g. '09/May/2025:15:00:40 +0100'
+	`\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}`,
+	// RFC 1123 and RFC 2822 e.g. 'Fri, 09 May 2025 15:00:40 GMT'
+	`(?:[A-Z][a-z]{2}, )?\d{1,2} [A-Z][a-z]{2} \d{4} \d{2}:\d{2}(?::\d{2})? (?:[+-]\d{4}|[A-Z]{3,4}\b)`,
+	// JavaScript Date.toString() e.g. 'Fri May 09 2025 15:00:40 GMT+0100 (British Summer Time)'
+	`[A-Z][a-z]{2} [A-Z][a-z]{2} \d{2} \d{4} \d{2}:\d{2}:\d{2} GMT[+-]\d{4}(?: \([^)]*\))?`,
+	// ANSI C and Unix date e.g. 'Fri May  9 15:00:40 BST 2025'
+	`[A-Z][a-z]{2} [A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}(?: [A-Z]{3,4})? \d{4}`,
+	// syslog e.g. 'May  9 15:00:40'
+	`[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}`,
+	// Go log e.g. '2025/05/09 15:00:40.123456'
+	`\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}(?:\.\d{1,6})?`,
+	// Unix seconds, milliseconds, microseconds and nanoseconds since 2001
+	`\d{10}(?:\d{3}|\d{6}|\d{9})?(?:\.\d{1,9})?\b`,
+}
+
+var timestampPattern = regexp.MustCompile(`^(?:` + strings.Join(timestampPatterns, "|") + `)`)
+
+// Length in bytes of the start of the text shaped like a date time that `Detect` may
+// recognise e.g. an RFC 3339 time or unix timestamp at the start of a log line, or 0
+// when the text doesn't start with one
+//
+// Shorter numbers and free text are never matched so natural language is not found
+func MatchTimestamp(text string) int {
+	if match := timestampPattern.FindStringIndex(text); match != nil {
+		return match[1]
+	}
+	return 0
+}
@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/locales/en_GB"
+)
+
+func TestDetect(t *testing.T) {
+	bst := time.FixedZone("", 3600)
+	scenarios := []struct {
+		input string
+		name  string
+		want  time.Time
+	}{
+		{input: "1746799240", name: "unix seconds", want: time.Unix(1746799240, 0)},
+		{input: "1746799240.5", name: "unix seconds", want: time.Unix(1746799240, 500000000)},
+		{input: "1746799240123", name: "unix milliseconds", want: time.UnixMilli(1746799240123)},
+		{input: "1746799240123456", name: "unix microseconds", want: time.UnixMicro(1746799240123456)},
+		{input: "1746799240123456789", name: "unix nanoseconds", want: time.Unix(0, 1746799240123456789)},
+		{input: "20250509", name: "ISO 8601 basic date", want: time.Date(2025, 5, 9, 0, 0, 0, 0, time.Local)},
+		{input: "20250509T150040+0100", name: "ISO 8601 basic date time", want: time.Date(2025, 5, 9, 15, 0, 40, 0, bst)},
+		{input: "2025-05-09T15:00:40.123+01:00", name: "RFC 3339", want: time.Date(2025, 5, 9, 15, 0, 40, 123000000, bst)},
+		{input: "2025-05-09T15:00:40Z", name: "RFC 3339", want: time.Date(2025, 5, 9, 15, 0, 40, 0, time.UTC)},
+		{input: "2025-05-09T15:00:40+0100", name: "ISO 8601", want: time.Date(2025, 5, 9, 15, 0, 40, 0, bst)},
+		{input: "2025-05-09T15:00", name: "ISO 8601", want: time.Date(2025, 5, 9, 15, 0, 0, 0, time.Local)},
+		{input: "2025-05-09", name: "ISO 8601 date", want: time.Date(2025, 5, 9, 0, 0, 0, 0, time.Local)},
+		{input: "2025", name: "ISO 8601 year", want: time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)},
+		{input: "946684800", name: "unix seconds", want: time.Unix(946684800, 0)},
+		{input: "2025-W19-5", name: "ISO 8601 week date", want: time.Date(2025, 5, 9, 0, 0, 0, 0, time.Local)},
+		{input: "2025-129", name: "ISO 8601 ordinal date", want: time.Date(2025, 5, 9, 0, 0, 0, 0, time.Local)},
+		{input: "2025-05-09 15:00:40.123 +0100 +0100 m=+0.000", name: "Go time.String()", want: time.Date(2025, 5, 9, 15, 0, 40, 123000000, bst)},
+		{input: "2025-05-09 15:00:40,123", name: "date time", want: time.Date(2025, 5, 9, 15, 0, 40, 123000000, time.Local)},
+		{input: "2025/05/09 15:00:40", name: "Go log", want: time.Date(2025, 5, 9, 15, 0, 40, 0, time.Local)},
+		{input: "Fri, 09 May 2025 15:00:40 +0100", name: "RFC 1123", want: time.Date(2025, 5, 9, 15, 0, 40, 0, bst)},
+		{input: "Fri, 9 May 2025 15:00:40 +0100", name: "RFC 2822", want: time.Date(2025, 5, 9, 15, 0, 40, 0, bst)},
+		{input: "Fri May  9 15:00:40 2025", name: "ANSI C", want: time.Date(2025, 5, 9, 15, 0, 40, 0, time.Local)},
+		{input: "fri, 09 May 2025 15:00:40 GMT", name: "RFC 1123", want: time.Date(2025, 5, 9, 15, 0, 40, 0, time.UTC)},
+		{input: "09/May/2025:15:00:40 +0100", name: "Common Log Format", want: time.Date(2025, 5, 9, 15, 0, 40, 0, bst)},
+		{input: "Fri May 09 2025 15:00:40 GMT+0100 (British Summer Time)", name: "JavaScript Date.toString()", want: time.Date(2025, 5, 9, 15, 0, 40, 0, bst)},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.input, func(t *testing.T) {
+			t.Parallel()
+			got, name, err := Detect(testCase.input, en_GB.New(), time.Local)
+			if err != nil {
+				t.Fatalf("Failed to detect '%s': %s", testCase.input, err)
+			}
+			if name != testCase.name {
+				t.Errorf("Expected '%s' to be detected as %s\nGot: %s", testCase.input, testCase.name, name)
+			}
+			if !got.Equal(testCase.want) {
+				t.Errorf("Expected '%s' to parse as %s\nGot: %s", testCase.input, testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestDetectNoYear(t *testing.T) {
+	got, name, err := Detect("May  9 15:00:40", en_GB.New(), time.Local)
+	if err != nil {
+		t.Fatalf("Failed to detect syslog timestamp: %s", err)
+	}
+	want := time.Date(time.Now().Year(), 5, 9, 15, 0, 40, 0, time.Local)
+	if name != "syslog" || !got.Equal(want) {
+		t.Errorf("Expected syslog timestamp %s\nGot: %s %s", want, name, got)
+	}
+}
+
+func TestDetectNaturalLocation(t *testing.T) {
+	kiritimati, _ := time.LoadLocation("Pacific/Kiritimati")
+	got, name, err := Detect("today midnight", en_GB.New(), kiritimati)
+	if err != nil {
+		t.Fatalf("Failed to detect natural language: %s", err)
+	}
+	year, month, day := time.Now().In(kiritimati).Date()
+	want := time.Date(year, month, day, 0, 0, 0, 0, kiritimati)
+	if name != "natural language" || !got.Equal(want) {
+		t.Errorf("Expected natural language %s\nGot: %s %s", want, name, got)
+	}
+}
+
+func TestInYear(t *testing.T) {
+	scenarios := []struct {
+		dt    time.Time
+		year  int
+		want  time.Time
+		valid bool
+	}{
+		{dt: time.Date(0, 5, 9, 15, 0, 40, 0, time.UTC), year: 2025, want: time.Date(2025, 5, 9, 15, 0, 40, 0, time.UTC), valid: true},
+		{dt: time.Date(0, 2, 29, 15, 0, 40, 0, time.UTC), year: 2024, want: time.Date(2024, 2, 29, 15, 0, 40, 0, time.UTC), valid: true},
+		{dt: time.Date(0, 2, 29, 15, 0, 40, 0, time.UTC), year: 2025},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := inYear(testCase.dt, testCase.year)
+			if (err == nil) != testCase.valid {
+				t.Fatalf("Moving %s to %d expected valid %t\nGot: %s %v", testCase.dt, testCase.year, testCase.valid, got, err)
+			}
+			if testCase.valid && !got.Equal(testCase.want) {
+				t.Errorf("Fail moving %s to %d\nGot:  %s\nwant: %s", testCase.dt, testCase.year, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestDetectInvalid(t *testing.T) {
+	for _, input := range []string{"", "nonsense", "2025-13-01", "2025-05-09T25:00", "1746799240abc", "12345", "-1", "Tue, 07 Jan 2024 15:00:40 GMT", "Tue, 7 Jan 2024 15:00:40 +0000", "Tue Jan  7 15:00:40 2024"} {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			got, name, err := Detect(input, en_GB.New(), time.Local)
+			if err == nil {
+				t.Errorf("Expected '%s' to fail detection\nGot: %s %s", input, name, got)
+			}
+		})
+	}
+}
+
+func TestMatchTimestamp(t *testing.T) {
+	scenarios := []struct {
+		input string
+		want  string
+	}{
+		{input: "1746799240 level=info msg=started", want: "1746799240"},
+		{input: "2025-05-09T15:00:40.123Z] GET /status 200 12ms", want: "2025-05-09T15:00:40.123Z"},
+		{input: "2025-05-09 15:00:40 +0100 BST took 3ms", want: "2025-05-09 15:00:40 +0100 BST"},
+		{input: "1746799240123456 started", want: "1746799240123456"},
+		{input: `09/May/2025:15:00:40 +0100] "GET / HTTP/1.1"`, want: "09/May/2025:15:00:40 +0100"},
+		{input: "May  9 15:00:40 host sshd[123]: accepted", want: "May  9 15:00:40"},
+		{input: "Fri, 09 May 2025 15:00:40 GMT", want: "Fri, 09 May 2025 15:00:40 GMT"},
+		{input: "174679924 ns", want: ""},
+		{input: "17467992401 ns", want: ""},
+		{input: "request at 2025-05-09", want: ""},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.input, func(t *testing.T) {
+			t.Parallel()
+			if got := testCase.input[:MatchTimestamp(testCase.input)]; got != testCase.want {
+				t.Errorf("Expected '%s' to start with the timestamp '%s'\nGot: '%s'", testCase.input, testCase.want, got)
+			}
+		})
+	}
+}
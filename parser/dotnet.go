@@ -0,0 +1,392 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/locales"
+)
+
+// Handler for parsing and formatting using .NET custom date and time format specifiers
+//
+// Literal text is escaped within single or double quotes or by a preceding '\' and a
+// layout of a single character is one of the standard formats e.g. 'o' or 'r'
+var DotNet DateHandlerString
+
+func init() {
+	mapExpanded := expandTokenMap(&tokenMapDotNet)
+	// A '.' before an 'F' token is left out along with a zero fraction so is read as
+	// part of the token
+	for digits := 1; digits <= 7; digits++ {
+		mapExpanded["."+strings.Repeat("F", digits)] = dotNetSeparatedFraction(digits)
+	}
+	DotNet = DateHandlerString{
+		quoteChars:      []rune{'\'', '"'},
+		escapePrefix:    '\\',
+		standardLayouts: standardLayoutsDotNet,
+		tokenDef:        tokenMapDotNet,
+		tokenGraph:      createTokenGraph(&mapExpanded),
+	}
+}
+
+// Standard formats following the patterns of the invariant culture
+var standardLayoutsDotNet = map[string]standardLayout{
+	"d": {Desc: "Short date - '05/09/2025'", layout: "MM/dd/yyyy"},
+	"D": {Desc: "Long date - 'Friday, 09 May 2025'", layout: "dddd, dd MMMM yyyy"},
+	"f": {Desc: "Long date with short time - 'Friday, 09 May 2025 15:00'", layout: "dddd, dd MMMM yyyy HH:mm"},
+	"F": {Desc: "Long date with long time - 'Friday, 09 May 2025 15:00:40'", layout: "dddd, dd MMMM yyyy HH:mm:ss"},
+	"g": {Desc: "Short date with short time - '05/09/2025 15:00'", layout: "MM/dd/yyyy HH:mm"},
+	"G": {Desc: "Short date with long time - '05/09/2025 15:00:40'", layout: "MM/dd/yyyy HH:mm:ss"},
+	"M": {Desc: "Month and day - 'May 09'", layout: "MMMM dd"},
+	"m": {Desc: "Month and day - 'May 09'", layout: "MMMM dd"},
+	"O": {Desc: "Round trip date time - '2025-05-09T15:00:40.1230000+01:00'", layout: "yyyy'-'MM'-'dd'T'HH':'mm':'ss'.'fffffffK"},
+	"o": {Desc: "Round trip date time - '2025-05-09T15:00:40.1230000+01:00'", layout: "yyyy'-'MM'-'dd'T'HH':'mm':'ss'.'fffffffK"},
+	"R": {Desc: "RFC 1123 date time in UTC - 'Fri, 09 May 2025 14:00:40 GMT'", layout: "ddd, dd MMM yyyy HH':'mm':'ss 'GMT'", utc: true},
+	"r": {Desc: "RFC 1123 date time in UTC - 'Fri, 09 May 2025 14:00:40 GMT'", layout: "ddd, dd MMM yyyy HH':'mm':'ss 'GMT'", utc: true},
+	"s": {Desc: "Sortable date time - '2025-05-09T15:00:40'", layout: "yyyy'-'MM'-'dd'T'HH':'mm':'ss"},
+	"t": {Desc: "Short time - '15:00'", layout: "HH:mm"},
+	"T": {Desc: "Long time - '15:00:40'", layout: "HH:mm:ss"},
+	"u": {Desc: "Universal sortable date time in UTC - '2025-05-09 14:00:40Z'", layout: "yyyy'-'MM'-'dd HH':'mm':'ss'Z'", utc: true},
+	"U": {Desc: "Long date with long time in UTC - 'Friday, 09 May 2025 14:00:40'", layout: "dddd, dd MMMM yyyy HH:mm:ss", utc: true},
+	"Y": {Desc: "Year and month - '2025 May'", layout: "yyyy MMMM"},
+	"y": {Desc: "Year and month - '2025 May'", layout: "yyyy MMMM"},
+}
+
+var tokenMapDotNet = TokenMap{
+	"%": {
+		Desc:   "Marks a single character layout as a custom specifier rather than a standard format - '%d'",
+		expand: func(dt time.Time, locale locales.Translator) string { return "" },
+		parse:  func(state *parseState, input string) (int, error) { return 0, nil },
+	},
+	"d": {
+		Desc:   "Day of month (1-31)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Day()) },
+		parse:  numberParser(1, 2, 1, 31, "day of month", setDay),
+	},
+	"dd": {
+		Desc:   "Day of month zero padded to two digits (01-31)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Day()) },
+		parse:  numberParser(2, 2, 1, 31, "day of month", setDay),
+	},
+	"ddd": {
+		Desc: "Abbreviated day of week name - 'Sun', 'Mon'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return locale.WeekdayAbbreviated(dt.Weekday())
+		},
+		parse: parseWeekdayName,
+	},
+	"dddd": {
+		Desc: "Day of week name - 'Sunday', 'Monday'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return locale.WeekdayWide(dt.Weekday())
+		},
+		parse: parseWeekdayName,
+	},
+	"f": {
+		Desc: "Fractional seconds to one digit (0-9)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return strconv.Itoa(dt.Nanosecond() / 100_000_000)
+		},
+		parse: fractionParser(1),
+	},
+	"ff": {
+		Desc: "Fractional seconds to two digits (00-99)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d", dt.Nanosecond()/10_000_000)
+		},
+		parse: fractionParser(2),
+	},
+	"fff": {
+		Desc: "Fractional seconds to three digits (000-999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%03d", dt.Nanosecond()/1_000_000)
+		},
+		parse: fractionParser(3),
+	},
+	"ffff": {
+		Desc: "Fractional seconds to four digits (0000-9999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%04d", dt.Nanosecond()/100_000)
+		},
+		parse: fractionParser(4),
+	},
+	"fffff": {
+		Desc: "Fractional seconds to five digits (00000-99999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%05d", dt.Nanosecond()/10_000)
+		},
+		parse: fractionParser(5),
+	},
+	"ffffff": {
+		Desc: "Fractional seconds to six digits (000000-999999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%06d", dt.Nanosecond()/1_000)
+		},
+		parse: fractionParser(6),
+	},
+	"fffffff": {
+		Desc: "Fractional seconds to seven digits (0000000-9999999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%07d", dt.Nanosecond()/100)
+		},
+		parse: fractionParser(7),
+	},
+	"F": {
+		Desc:   "Fractional seconds to one digit without trailing zeros, nothing when zero (0-9)",
+		expand: func(dt time.Time, locale locales.Translator) string { return dotNetTrimmedFraction(dt, 1) },
+		parse:  dotNetOptionalFraction(1),
+	},
+	"FF": {
+		Desc:   "Fractional seconds to two digits without trailing zeros, nothing when zero (0-99)",
+		expand: func(dt time.Time, locale locales.Translator) string { return dotNetTrimmedFraction(dt, 2) },
+		parse:  dotNetOptionalFraction(2),
+	},
+	"FFF": {
+		Desc:   "Fractional seconds to three digits without trailing zeros, nothing when zero (0-999)",
+		expand: func(dt time.Time, locale locales.Translator) string { return dotNetTrimmedFraction(dt, 3) },
+		parse:  dotNetOptionalFraction(3),
+	},
+	"FFFF": {
+		Desc:   "Fractional seconds to four digits without trailing zeros, nothing when zero (0-9999)",
+		expand: func(dt time.Time, locale locales.Translator) string { return dotNetTrimmedFraction(dt, 4) },
+		parse:  dotNetOptionalFraction(4),
+	},
+	"FFFFF": {
+		Desc:   "Fractional seconds to five digits without trailing zeros, nothing when zero (0-99999)",
+		expand: func(dt time.Time, locale locales.Translator) string { return dotNetTrimmedFraction(dt, 5) },
+		parse:  dotNetOptionalFraction(5),
+	},
+	"FFFFFF": {
+		Desc:   "Fractional seconds to six digits without trailing zeros, nothing when zero (0-999999)",
+		expand: func(dt time.Time, locale locales.Translator) string { return dotNetTrimmedFraction(dt, 6) },
+		parse:  dotNetOptionalFraction(6),
+	},
+	"FFFFFFF": {
+		Desc:   "Fractional seconds to seven digits without trailing zeros, nothing when zero (0-9999999)",
+		expand: func(dt time.Time, locale locales.Translator) string { return dotNetTrimmedFraction(dt, 7) },
+		parse:  dotNetOptionalFraction(7),
+	},
+	"g": {
+		Desc: "Era - 'B.C.', 'A.D.'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			if dt.Year() < 1 {
+				return "B.C."
+			}
+			return "A.D."
+		},
+		parse: func(state *parseState, input string) (int, error) {
+			idx, consumed, ok := parseName(input, []string{"B.C.", "BC", "A.D.", "AD"})
+			if !ok {
+				return 0, fmt.Errorf("Unable to parse era")
+			}
+			state.bc = idx < 2
+			return consumed, nil
+		},
+		aliases: []string{"gg"},
+	},
+	"h": {
+		Desc: "Hour in 12 hour format (1-12)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return strconv.Itoa(clockHour(dt.Hour()%12, 12))
+		},
+		parse: numberParser(1, 2, 1, 12, "hour", setHour),
+	},
+	"hh": {
+		Desc: "Hour in 12 hour format zero padded to two digits (01-12)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d", clockHour(dt.Hour()%12, 12))
+		},
+		parse: numberParser(2, 2, 1, 12, "hour", setHour),
+	},
+	"H": {
+		Desc:   "Hour in 24 hour format (0-23)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Hour()) },
+		parse:  numberParser(1, 2, 0, 23, "hour", setHour),
+	},
+	"HH": {
+		Desc:   "Hour in 24 hour format zero padded to two digits (00-23)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Hour()) },
+		parse:  numberParser(2, 2, 0, 23, "hour", setHour),
+	},
+	"K": {
+		Desc: "Time zone offset or 'Z' for UTC, nothing when parsing without one - 'Z', '+01:00', '-03:30'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			if dt.Location() == time.UTC {
+				return "Z"
+			}
+			return formatOffset(dt, ":", true, "")
+		},
+		parse: func(state *parseState, input string) (int, error) {
+			if len(input) == 0 || !strings.ContainsRune("Zz+-", rune(input[0])) {
+				return 0, nil
+			}
+			return parseOffset(state, input)
+		},
+	},
+	"m": {
+		Desc:   "Minutes (0-59)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Minute()) },
+		parse:  numberParser(1, 2, 0, 59, "minute", setMinute),
+	},
+	"mm": {
+		Desc:   "Minutes zero padded to two digits (00-59)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Minute()) },
+		parse:  numberParser(2, 2, 0, 59, "minute", setMinute),
+	},
+	"M": {
+		Desc:   "Month number (1-12)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.Month())) },
+		parse:  numberParser(1, 2, 1, 12, "month", setMonth),
+	},
+	"MM": {
+		Desc:   "Month number zero padded to two digits (01-12)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Month()) },
+		parse:  numberParser(2, 2, 1, 12, "month", setMonth),
+	},
+	"MMM": {
+		Desc: "Abbreviated month name - 'Jan', 'Feb'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return locale.MonthAbbreviated(dt.Month())
+		},
+		parse: parseMonthName,
+	},
+	"MMMM": {
+		Desc: "Month name - 'January', 'February'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return locale.MonthWide(dt.Month())
+		},
+		parse: parseMonthName,
+	},
+	"s": {
+		Desc:   "Seconds (0-59)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Second()) },
+		parse:  numberParser(1, 2, 0, 59, "second", setSecond),
+	},
+	"ss": {
+		Desc:   "Seconds zero padded to two digits (00-59)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Second()) },
+		parse:  numberParser(2, 2, 0, 59, "second", setSecond),
+	},
+	"t": {
+		Desc: "Meridiem abbreviated to one character - 'A', 'P'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			if dt.Hour() < 12 {
+				return "A"
+			}
+			return "P"
+		},
+		parse: func(state *parseState, input string) (int, error) {
+			idx, consumed, ok := parseName(input, []string{"A", "P"})
+			if !ok {
+				return 0, fmt.Errorf("Unable to parse meridiem")
+			}
+			state.meridiem = meridiemAM
+			if idx == 1 {
+				state.meridiem = meridiemPM
+			}
+			return consumed, nil
+		},
+	},
+	"tt": {
+		Desc: "Meridiem - 'AM', 'PM'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			if dt.Hour() < 12 {
+				return "AM"
+			}
+			return "PM"
+		},
+		parse: parseMeridiem,
+	},
+	"y": {
+		Desc:   "Year number truncated to last two digits without padding - '99', '7'",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Year() % 100) },
+		parse: numberParser(1, 2, 0, 99, "year", func(state *parseState, year int) {
+			setYear(state, expandTwoDigitYear(year, 49))
+		}),
+	},
+	"yy": {
+		Desc:   "Year number truncated to last two digits - '99', '07'",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Year()%100) },
+		parse: numberParser(2, 2, 0, 99, "year", func(state *parseState, year int) {
+			setYear(state, expandTwoDigitYear(year, 49))
+		}),
+	},
+	"yyy": {
+		Desc:   "Year number zero padded to at least three digits - '1999', '007'",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%03d", dt.Year()) },
+		parse:  yearParser(3, 4),
+	},
+	"yyyy": {
+		Desc:   "Year number zero padded to four digits - '1999', '0007'",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%04d", dt.Year()) },
+		parse:  yearParser(4, 4),
+	},
+	"yyyyy": {
+		Desc:   "Year number zero padded to five digits - '01999', '00007'",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%05d", dt.Year()) },
+		parse:  yearParser(5, 5),
+	},
+	"z": {
+		Desc: "Time zone offset in hours without padding - '+1', '-3'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			_, offsetSeconds := dt.Zone()
+			return fmt.Sprintf("%+d", offsetSeconds/(60*60))
+		},
+		parse: parseOffset,
+	},
+	"zz": {
+		Desc: "Time zone offset in hours - '+01', '-03'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			_, offsetSeconds := dt.Zone()
+			return fmt.Sprintf("%+03d", offsetSeconds/(60*60))
+		},
+		parse: parseOffset,
+	},
+	"zzz": {
+		Desc:   "Time zone offset - '+01:00', '-03:30'",
+		expand: func(dt time.Time, locale locales.Translator) string { return formatOffset(dt, ":", true, "") },
+		parse:  parseOffset,
+	},
+}
+
+// Fraction of a second to `digits` digits with trailing zeros removed
+func dotNetTrimmedFraction(dt time.Time, digits int) string {
+	fraction := fmt.Sprintf("%09d", dt.Nanosecond())[:digits]
+	return strings.TrimRight(fraction, "0")
+}
+
+// Token for an 'F' token and the '.' before it which are both left out when the
+// fraction is zero
+func dotNetSeparatedFraction(digits int) FormatToken[string] {
+	parseFraction := fractionParser(digits)
+	return FormatToken[string]{
+		Desc: fmt.Sprintf("Fractional seconds to %d digits without trailing zeros after a '.', nothing when zero", digits),
+		expand: func(dt time.Time, locale locales.Translator) string {
+			if fraction := dotNetTrimmedFraction(dt, digits); fraction != "" {
+				return "." + fraction
+			}
+			return ""
+		},
+		parse: func(state *parseState, input string) (int, error) {
+			if len(input) < 2 || input[0] != '.' || input[1] < '0' || input[1] > '9' {
+				return 0, nil
+			}
+			consumed, err := parseFraction(state, input[1:])
+			return consumed + 1, err
+		},
+	}
+}
+
+// Creates a parse function reading up to `maxLen` digits of a fraction of a second
+// which may be missing entirely
+func dotNetOptionalFraction(maxLen int) func(state *parseState, input string) (int, error) {
+	parseFraction := fractionParser(maxLen)
+	return func(state *parseState, input string) (int, error) {
+		if len(input) == 0 || input[0] < '0' || input[0] > '9' {
+			return 0, nil
+		}
+		return parseFraction(state, input)
+	}
+}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/locales/en_GB"
+)
+
+func TestFormatDotNet(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	stJohns := time.FixedZone("NDT", -9000)
+	dt := time.Date(2025, 5, 9, 15, 0, 40, 123_456_789, london)
+	scenarios := []struct {
+		dt     time.Time
+		format string
+		want   string
+	}{
+		{dt: dt, format: "o", want: "2025-05-09T15:00:40.1234567+01:00"},
+		{dt: dt.UTC(), format: "o", want: "2025-05-09T14:00:40.1234567Z"},
+		{dt: dt, format: "r", want: "Fri, 09 May 2025 14:00:40 GMT"},
+		{dt: dt, format: "s", want: "2025-05-09T15:00:40"},
+		{dt: dt, format: "u", want: "2025-05-09 14:00:40Z"},
+		{dt: dt, format: "D", want: "Friday, 09 May 2025"},
+		{dt: dt, format: "F", want: "Friday, 09 May 2025 15:00:40"},
+		{dt: dt, format: "%d", want: "9"},
+		{dt: dt, format: "ddd dd MMM yyyy hh:mm tt zzz", want: "Fri 09 May 2025 03:00 PM +01:00"},
+		{dt: dt, format: "HH:mm:ss.fff FFFFFFF t y", want: "15:00:40.123 1234567 P 25"},
+		{dt: time.Date(2025, 5, 9, 15, 0, 40, 500_000_000, stJohns), format: "HH:mm:ss.FFF z zz K", want: "15:00:40.5 -2 -02 -02:30"},
+		{dt: time.Date(2025, 5, 9, 15, 0, 40, 0, time.UTC), format: "HH:mm:ss.FFFK", want: "15:00:40Z"},
+		{dt: dt, format: `\d\a\y d "of" MMMM 'at' h`, want: "day 9 of May at 3"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := DotNet.Format(testCase.dt, en_GB.New(), &testCase.format); got != testCase.want {
+				t.Errorf("Fail formatting %q\nGot:  %s\nwant: %s", testCase.format, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseDotNet(t *testing.T) {
+	scenarios := []testCase{
+		{input: "2025-05-09T15:00:40.1234567+01:00", format: "o", want: time.Date(2025, 5, 9, 15, 0, 40, 123_456_700, time.FixedZone("", 3600))},
+		{input: "2025-05-09T15:00:40.1234567Z", format: "o", want: time.Date(2025, 5, 9, 15, 0, 40, 123_456_700, time.UTC)},
+		{input: "2025-05-09T15:00:40.0000000", format: "o", want: time.Date(2025, 5, 9, 15, 0, 40, 0, time.Local)},
+		{input: "Fri, 09 May 2025 14:00:40 GMT", format: "r", want: time.Date(2025, 5, 9, 14, 0, 40, 0, time.UTC)},
+		{input: "2025-05-09 14:00:40Z", format: "u", want: time.Date(2025, 5, 9, 14, 0, 40, 0, time.UTC)},
+		{input: "Friday, 09 May 2025", format: "D", want: time.Date(2025, 5, 9, 0, 0, 0, 0, time.Local)},
+		{input: "09 May 25 3:00 PM", format: "dd MMM yy h:mm tt", want: time.Date(2025, 5, 9, 15, 0, 0, 0, time.Local)},
+		{input: "09/05/1997 15:00:40 +01:00", format: "dd/MM/yyyy HH:mm:ss zzz", want: time.Date(1997, 5, 9, 15, 0, 40, 0, time.FixedZone("", 3600))},
+		{input: "15:00:40Z 09/05/2025", format: "HH:mm:ss.FFFK dd/MM/yyyy", want: time.Date(2025, 5, 9, 15, 0, 40, 0, time.UTC)},
+		{input: "15:00:40.25Z 09/05/2025", format: "HH:mm:ss.FFFK dd/MM/yyyy", want: time.Date(2025, 5, 9, 15, 0, 40, 250_000_000, time.UTC)},
+		{input: "day 9 of May 2025", format: `\d\a\y d "of" MMMM yyyy`, want: time.Date(2025, 5, 9, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := DotNet.Parse(testCase.input, testCase.format, en_GB.New())
+			if err != nil {
+				t.Errorf("Failed to parse: '%s' with format '%s'\n%s", testCase.input, testCase.format, err)
+				return
+			}
+			if got.Compare(testCase.want) != 0 {
+				t.Errorf("Fail\nGot:  %s\nwant: %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseDotNetInvalid(t *testing.T) {
+	scenarios := []testCase{
+		{input: "2025-02-30", format: "yyyy-MM-dd"},
+		{input: "2025-05-09 15:00:40", format: "s"},
+		{input: "Thu, 09 May 2025 14:00:40 GMT", format: "r"},
+		{input: "13:00 PM", format: "hh:mm tt"},
+		{input: "Q", format: "Q"},
+		{input: "%", format: "%"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := DotNet.Parse(testCase.input, testCase.format, en_GB.New())
+			if err == nil {
+				t.Errorf("Expected '%s' to fail parsing with format '%s'\nGot: %s", testCase.input, testCase.format, got)
+			}
+		})
+	}
+}
+
+func TestValidateLayoutDotNet(t *testing.T) {
+	scenarios := []struct {
+		layout string
+		valid  bool
+	}{
+		{layout: "o", valid: true},
+		{layout: "%d", valid: true},
+		{layout: "dd MMM", valid: true},
+		{layout: "Q", valid: false},
+		{layout: "d", valid: true},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if err := DotNet.ValidateLayout(testCase.layout); (err == nil) != testCase.valid {
+				t.Errorf("Validating %q expected valid %t\nGot: %v", testCase.layout, testCase.valid, err)
+			}
+		})
+	}
+}
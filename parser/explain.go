@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Section of a layout as read by a formatter
+type LayoutSection struct {
+	// Text exactly as written in the layout including any prefix or escape characters
+	Raw string `json:"raw"`
+	// Text output as is for literal sections
+	Literal string `json:"literal,omitempty"`
+	// Description of a recognised token
+	Desc  string `json:"description,omitempty"`
+	Token bool   `json:"token"`
+	// Token can be read when parsing as well as output when formatting
+	Parsable bool `json:"parsable,omitempty"`
+	Escaped  bool `json:"escaped,omitempty"`
+	// Escaped section missing its closing character which runs to the end of the layout
+	Unterminated bool `json:"unterminated,omitempty"`
+	// Prefixed section that does not match any known token
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// Splits a layout into its tokens and literal sections as read by the named formatter
+// e.g. moment reads 'Do [of] MMMM' as the token 'Do', the literal ' ', the escaped
+// literal 'of', the literal ' ' and the token 'MMMM'
+func LayoutSections(layout, formatter string) ([]LayoutSection, error) {
+	var tokens []layoutToken
+	switch strings.ToLower(formatter) {
+	case "moment", "momentjs":
+		tokens = MomentJs.tokenise(layout)
+	case "luxon":
+		tokens = Luxon.tokenise(layout)
+	case "java":
+		tokens = Java.tokenise(layout)
+	case "dotnet", ".net", "csharp":
+		// Standard formats are explained by the layout they stand for
+		expanded, _, err := DotNet.expandLayout(layout)
+		if err != nil {
+			return nil, err
+		}
+		tokens = DotNet.tokenise(expanded)
+	case "c", "strftime", "strptime", "go:strftime", "go:strptime":
+		// The C formatter shares its tokens with the Go implementation of strftime
+		tokens = GoStrptime.tokenise(layout)
+	case "go":
+		return goLayoutSections(layout), nil
+	default:
+		return nil, fmt.Errorf("%q is not a formatter with tokens", formatter)
+	}
+
+	sections := make([]LayoutSection, len(tokens))
+	for idx, token := range tokens {
+		sections[idx] = LayoutSection{
+			Raw:          token.raw,
+			Literal:      token.literal,
+			Token:        token.def != nil,
+			Escaped:      token.escaped,
+			Unterminated: token.unterminated,
+			Unknown:      token.unknown,
+		}
+		if token.def != nil {
+			sections[idx].Desc = token.def.Desc
+			sections[idx].Parsable = token.def.parse != nil
+		}
+		if token.optionalStart {
+			sections[idx].Desc = "Start of a section that is optional when parsing"
+		} else if token.optionalEnd {
+			sections[idx].Desc = "End of a section that is optional when parsing"
+		}
+	}
+	return sections, nil
+}
+
+// Splits a Go layout into sections following the tokens recognised by `time.Format`
+func goLayoutSections(layout string) []LayoutSection {
+	var sections []LayoutSection
+	for idx := 0; idx < len(layout); {
+		tokenLen := goTokenLength(layout[idx:])
+		if tokenLen == 0 {
+			last := len(sections) - 1
+			if last >= 0 && !sections[last].Token {
+				sections[last].Raw += layout[idx : idx+1]
+				sections[last].Literal = sections[last].Raw
+			} else {
+				sections = append(sections, LayoutSection{Raw: layout[idx : idx+1], Literal: layout[idx : idx+1]})
+			}
+			idx++
+			continue
+		}
+
+		token := layout[idx : idx+tokenLen]
+		def := Go.tokenDef[token]
+		if token[0] == '.' || token[0] == ',' {
+			def = Go.tokenDef["."+token[1:2]+token[1:2]+token[1:2]]
+		}
+		// Every token of a Go layout is understood by `time.Parse`
+		sections = append(sections, LayoutSection{Raw: token, Desc: def.Desc, Token: true, Parsable: true})
+		idx += tokenLen
+	}
+	return sections
+}
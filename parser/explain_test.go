@@ -0,0 +1,37 @@
+package parser
+
+import "testing"
+
+func TestLayoutSections(t *testing.T) {
+	scenarios := []struct {
+		layout    string
+		formatter string
+		want      []string
+	}{
+		{layout: "%-e %Oy", formatter: "strftime", want: []string{"%-e", " ", "%Oy"}},
+		{layout: "%_H:%0M", formatter: "go:strftime", want: []string{"%_H", ":", "%0M"}},
+		{layout: "Do [of] MMMM", formatter: "moment", want: []string{"Do", " ", "[of]", " ", "MMMM"}},
+		{layout: "yyyy[-MM]", formatter: "java", want: []string{"yyyy", "[", "-", "MM", "]"}},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.layout, func(t *testing.T) {
+			t.Parallel()
+			sections, err := LayoutSections(testCase.layout, testCase.formatter)
+			if err != nil {
+				t.Fatalf("Failed to explain '%s'\n%s", testCase.layout, err)
+			}
+			if len(sections) != len(testCase.want) {
+				t.Fatalf("Expected %d sections in '%s'\nGot: %v", len(testCase.want), testCase.layout, sections)
+			}
+			for idx, section := range sections {
+				if section.Raw != testCase.want[idx] {
+					t.Errorf("Expected section %d of '%s' to be '%s'\nGot: '%s'", idx, testCase.layout, testCase.want[idx], section.Raw)
+				}
+				if section.Unknown {
+					t.Errorf("Expected section '%s' of '%s' to be known", section.Raw, testCase.layout)
+				}
+			}
+		})
+	}
+}
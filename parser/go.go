@@ -11,7 +11,7 @@ var Go = DateHandlerTokenWrapper{
 	format: func(dt time.Time, locale locales.Translator, formatStr string) string {
 		return dt.Format(formatStr)
 	},
-	parse: func(input, format string) (time.Time, error) {
+	parse: func(input, format string, locale locales.Translator) (time.Time, error) {
 		return time.Parse(format, input)
 	},
 	tokenDef: map[string]FormatToken[string]{
@@ -106,5 +106,15 @@ var Go = DateHandlerTokenWrapper{
 			Desc: "Day of week name shortened to three characters",
 		},
 		"PM": {Desc: "AM/PM label"},
+		"pm": {Desc: "am/pm label in lower case"},
+		"MST": {
+			Desc: "Time zone abbreviation - 'GMT', 'CEST'",
+		},
+		".000": {
+			Desc: "Fractional seconds to as many digits as there are zeros, also written as ',000'",
+		},
+		".999": {
+			Desc: "Fractional seconds to at most as many digits as there are nines with trailing zeros removed, also written as ',999'",
+		},
 	},
 }
@@ -16,72 +16,141 @@ import (
 // Most formatting tokens are supported for English locales and are near one to one with the
 // C equivalents with some small differences when it comes to spacing between characters
 //
-// Parsing is very much a work in progress only supporting a very limited set.
-//
-// Due to these incompatibilities it's recommend to use the CStr parser if possible
-// for the time being
+// Every token can be parsed following the glibc `strptime` rules; numeric values may
+// be padded with leading whitespace, names are matched ignoring case in either their
+// full or abbreviated forms and two digit years from 69-99 are in the 1900s. Unlike
+// glibc the ISO week tokens `%G`, `%g` and `%V` are used to determine the date
 var GoStrptime DateHandlerPrefix
 
 func init() {
 	mapExpanded := expandTokenMap(&tokenMapStrftime)
 	GoStrptime = DateHandlerPrefix{
 		Prefix:     '%',
+		flags:      strftimeFlags,
 		tokenDef:   tokenMapStrftime,
 		tokenGraph: createTokenGraph(&mapExpanded),
 	}
 }
 
+// glibc flags written between '%' and a token e.g. '%-d'
+//
+// As with glibc `strptime` the flags are ignored when parsing
+var strftimeFlags = map[rune]tokenFlag{
+	'-': {Desc: "without padding", apply: func(output string) string { return strftimePad(output, "") }},
+	'_': {Desc: "padded with spaces", apply: func(output string) string { return strftimePad(output, " ") }},
+	'0': {Desc: "padded with zeros", apply: func(output string) string { return strftimePad(output, "0") }},
+	'^': {Desc: "in upper case", apply: strings.ToUpper},
+	'#': {Desc: "with the case swapped", apply: func(output string) string {
+		// glibc swaps mixed case names such as 'Monday' to upper case
+		if strings.ToUpper(output) != output {
+			return strings.ToUpper(output)
+		}
+		return strings.ToLower(output)
+	}},
+}
+
+// Replaces the zero or space padding at the start of a number with `padding`
+func strftimePad(output, padding string) string {
+	digits := strings.TrimLeft(output, "0 ")
+	if digits == "" && output != "" {
+		// The number is itself zero
+		digits = "0"
+	}
+	if digits == "" || digits[0] < '0' || digits[0] > '9' {
+		return output
+	}
+	return strings.Repeat(padding, len(output)-len(digits)) + digits
+}
+
+// Parse functions shared between tokens and their alternative 'E' and 'O' forms
+var (
+	strptimeDay          = spacePadded(numberParser(1, 2, 1, 31, "day of month", setDay))
+	strptimeMonth        = spacePadded(numberParser(1, 2, 1, 12, "month", setMonth))
+	strptimeYear         = spacePadded(numberParser(1, 4, 0, 9999, "year", setYear))
+	strptimeCentury      = spacePadded(numberParser(1, 2, 0, 99, "century", setCentury))
+	strptimeHour24       = spacePadded(numberParser(1, 2, 0, 23, "hour", setHour))
+	strptimeHour12       = spacePadded(numberParser(1, 2, 1, 12, "hour", setHour))
+	strptimeMinute       = spacePadded(numberParser(1, 2, 0, 59, "minute", setMinute))
+	strptimeSecond       = spacePadded(numberParser(1, 2, 0, 60, "second", setSecond))
+	strptimeWeekday      = spacePadded(numberParser(1, 1, 0, 6, "day of week", setWeekday))
+	strptimeTwoDigitYear = spacePadded(numberParser(1, 2, 0, 99, "year", func(state *parseState, year int) {
+		setYear(state, expandTwoDigitYear(year, 68))
+	}))
+	strptimeSundayWeek = spacePadded(numberParser(1, 2, 0, 53, "week", func(state *parseState, week int) {
+		setYearWeek(state, week, time.Sunday)
+	}))
+	strptimeMondayWeek = spacePadded(numberParser(1, 2, 0, 53, "week", func(state *parseState, week int) {
+		setYearWeek(state, week, time.Monday)
+	}))
+)
+
+// Creates a parse function for tokens equivalent to a combination of other tokens
+// e.g. '%D' is equivalent to '%m/%d/%y'
+func strptimeLayout(layout string) func(state *parseState, input string) (int, error) {
+	return func(state *parseState, input string) (int, error) {
+		return matchTokens(state, input, GoStrptime.parseLayoutTokens(layout))
+	}
+}
+
+func parsePercent(state *parseState, input string) (int, error) {
+	if !strings.HasPrefix(input, "%") {
+		return 0, fmt.Errorf("Expected '%%'")
+	}
+	return 1, nil
+}
+
 var tokenMapStrftime = TokenMap{
 	"%": {
 		Desc:   "'%' character literal",
 		expand: func(dt time.Time, locale locales.Translator) string { return "%" },
+		parse:  parsePercent,
 	},
 	"A": {
 		Desc: "Weekday name - 'Monday', 'Tuesday'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.WeekdayWide(dt.Weekday())
 		},
+		parse: parseWeekdayName,
 	},
 	"a": {
 		Desc: "Weekday name truncated to three characters - 'Mon', 'Tue'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.WeekdayAbbreviated(dt.Weekday())
 		},
+		parse: parseWeekdayName,
 	},
 	"B": {
 		Desc:   "Month name - 'January', 'February'",
 		expand: func(dt time.Time, locale locales.Translator) string { return dt.Month().String() },
+		parse:  parseMonthName,
 	},
 	"b": {
 		Desc:    "Month month name truncated to three characters - 'Jan', 'Feb'",
 		expand:  func(dt time.Time, locale locales.Translator) string { return dt.Month().String()[:3] },
+		parse:   parseMonthName,
 		aliases: []string{"h"},
 	},
 	"c": {
 		Desc:   "Date and time for the current locale (hardcoded to UK format currently)",
 		expand: func(dt time.Time, locale locales.Translator) string { return dt.Format("Mon _2 Jan 15:04:05 2006") },
+		parse:  strptimeLayout("%a %e %b %H:%M:%S %Y"),
 	},
 	"C": {
 		Desc:   "The century number (0–99)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Year() / 100) },
+		parse:  strptimeCentury,
 	},
 	"d": {
 		Desc:   "Day of month zero padded to two digits (01-31)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Day()) },
-		parse: func(dt *time.Time, str *string) (int, error) {
-			date, err := strconv.Atoi((*str)[:2])
-			if err != nil {
-				return 0, fmt.Errorf("Unable to parse date")
-			}
-			*dt = time.Date(dt.Year(), dt.Month(), date, dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), dt.Location())
-			return 1, nil
-		},
+		parse:  strptimeDay,
 	},
 	"D": {
 		Desc: "American style date (month first) equivalent to '%m/%d/%y' where the year is truncated to the last two digits - '01/31/97', '02/28/01'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d/%02d/%02d", dt.Month(), dt.Day(), dt.Year()%100)
 		},
+		parse: strptimeLayout("%m/%d/%y"),
 	},
 	"e": {
 		Desc: "Day of month space padded to two characters ( 1-31)",
@@ -92,20 +161,14 @@ var tokenMapStrftime = TokenMap{
 			}
 			return fmt.Sprintf(fmtstr, dt.Day())
 		},
-		parse: func(dt *time.Time, str *string) (int, error) {
-			date, err := strconv.Atoi(strings.TrimSpace((*str)[:2]))
-			if err != nil {
-				return 0, fmt.Errorf("Unable to parse date: %s", err)
-			}
-			*dt = time.Date(dt.Year(), dt.Month(), date, dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), dt.Location())
-			return 1, nil
-		},
+		parse: strptimeDay,
 	},
 	"F": {
 		Desc: "Date in year-month-day format equivalent to '%Y-%m-%d' - '2024-01-04', '1997-10-31'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%d-%02d-%02d", dt.Year(), dt.Month(), dt.Day())
 		},
+		parse: strptimeLayout("%Y-%m-%d"),
 	},
 	"g": {
 		Desc: "ISO week year shortened to the last two digits (00-99) ",
@@ -113,6 +176,9 @@ var tokenMapStrftime = TokenMap{
 			year, _ := dt.ISOWeek()
 			return fmt.Sprintf("%02d", year%100)
 		},
+		parse: spacePadded(numberParser(1, 2, 0, 99, "ISO week year", func(state *parseState, year int) {
+			setISOYear(state, expandTwoDigitYear(year, 68))
+		})),
 	},
 	"G": {
 		Desc: "ISO week year - '1999', '2007'",
@@ -120,10 +186,12 @@ var tokenMapStrftime = TokenMap{
 			year, _ := dt.ISOWeek()
 			return fmt.Sprintf("%d", year)
 		},
+		parse: spacePadded(numberParser(1, 4, 0, 9999, "ISO week year", setISOYear)),
 	},
 	"H": {
 		Desc:   "Hour in 24 hour format zero padded to two digits (00-23)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Hour()) },
+		parse:  strptimeHour24,
 	},
 	"I": {
 		Desc: "Hour in 12 hour format zero padded to two digits (01-12)",
@@ -134,10 +202,12 @@ var tokenMapStrftime = TokenMap{
 			}
 			return fmt.Sprintf("%02d", hour)
 		},
+		parse: strptimeHour12,
 	},
 	"j": {
 		Desc:   "Day of year zero padded to three digits (001-366)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%03d", dt.YearDay()) },
+		parse:  spacePadded(numberParser(1, 3, 1, 366, "day of year", setYearDay)),
 	},
 	"k": {
 		Desc: "Hour in 24 hour format space padded to two digits ( 0-23)",
@@ -148,6 +218,7 @@ var tokenMapStrftime = TokenMap{
 			}
 			return fmt.Sprintf(" %d", hour)
 		},
+		parse: strptimeHour24,
 	},
 	"l": {
 		Desc: "Hour in 12 hour format space padded to two digits ( 0-12)",
@@ -161,26 +232,22 @@ var tokenMapStrftime = TokenMap{
 			}
 			return fmt.Sprintf(" %d", hour)
 		},
+		parse: strptimeHour12,
 	},
 	"m": {
 		Desc:   "Month number zero padded to two digits (01-12)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Month()) },
-		parse: func(dt *time.Time, str *string) (int, error) {
-			month, err := strconv.Atoi((*str)[:2])
-			if err != nil {
-				return 0, fmt.Errorf("Unable to parse month")
-			}
-			*dt = time.Date(dt.Year(), time.Month(month), dt.Day(), dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), dt.Location())
-			return 1, nil
-		},
+		parse:  strptimeMonth,
 	},
 	"M": {
 		Desc:   "Minutes zero padded to two digits (00-59)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Minute()) },
+		parse:  strptimeMinute,
 	},
 	"n": {
 		Desc:   "Newline whitespace - '\\n'",
 		expand: func(dt time.Time, locale locales.Translator) string { return "\n" },
+		parse:  parseWhitespace,
 	},
 	"p": {
 		Desc: "The locale's equivalent of AM or PM (hardcoded to English am/pm)",
@@ -191,6 +258,7 @@ var tokenMapStrftime = TokenMap{
 			}
 			return "p.m."
 		},
+		parse: parseMeridiem,
 	},
 	"r": {
 		Desc: "12 hour time represented as hours, minutes, seconds and am/pm equivalent to \"%I:%M:%S %p\" (hardcoded to English am/pm) - '11:24:52 pm', '04:09:20 am'",
@@ -206,36 +274,43 @@ var tokenMapStrftime = TokenMap{
 			}
 			return fmt.Sprintf("%02d:%02d:%02d %s", hour, dt.Minute(), dt.Second(), ampm)
 		},
+		parse: strptimeLayout("%I:%M:%S %p"),
 	},
 	"R": {
 		Desc: "Time represented as hours and minutes equivalent to %H:%M - '12:24', '04:09'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d:%02d", dt.Hour(), dt.Minute())
 		},
+		parse: strptimeLayout("%H:%M"),
 	},
 	"s": {
 		Desc:   "Seconds since the unix epoch 1970-01-01 00:00:00 +0000 (UTC)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%d", dt.Unix()) },
+		parse:  spacePadded(unixParser(time.Second)),
 	},
 	"S": {
 		Desc:   "Seconds zero padded to two digits (00-60; 60 may occur for for leap seconds)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Second()) },
+		parse:  strptimeSecond,
 	},
 	"t": {
 		Desc:   "Tab whitespace - '\\t'",
 		expand: func(dt time.Time, locale locales.Translator) string { return "\t" },
+		parse:  parseWhitespace,
 	},
 	"T": {
 		Desc: "Time represented as hours, minutes and seconds equivalent to %H:%M:%S - '12:34:03', '04:09:59'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d:%02d:%02d", dt.Hour(), dt.Minute(), dt.Second())
 		},
+		parse: strptimeLayout("%H:%M:%S"),
 	},
 	"u": {
 		Desc: "Day of week where Monday = 1 and Sunday = 7 (1-7)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return strconv.Itoa((int(dt.Weekday())+6)%7 + 1)
 		},
+		parse: spacePadded(numberParser(1, 1, 1, 7, "day of week", setWeekday)),
 	},
 	"U": {
 		Desc: "Week number of the year where the first Sunday of January is considered week 1 - (00-53)",
@@ -247,6 +322,7 @@ var tokenMapStrftime = TokenMap{
 			weekDiff := int((midnight.Sub(firstSundayJan).Hours() / 24 / 7) + 1)
 			return fmt.Sprintf("%02d", weekDiff)
 		},
+		parse: strptimeSundayWeek,
 	},
 	"v": {
 		Desc: "Date with space padded day; truncated month name and year equivalent to %d-%b-%Y - ' 4-Jan-1997'",
@@ -258,6 +334,7 @@ var tokenMapStrftime = TokenMap{
 			}
 			return fmt.Sprintf(formatStr, dt.Day(), locale.MonthWide(dt.Month())[:3], dt.Year())
 		},
+		parse: strptimeLayout("%e-%b-%Y"),
 	},
 	"V": {
 		Desc: "ISO8601 week number of the year zero padded to two digits - (01-53)",
@@ -265,10 +342,12 @@ var tokenMapStrftime = TokenMap{
 			_, week := dt.ISOWeek()
 			return fmt.Sprintf("%02d", week)
 		},
+		parse: spacePadded(numberParser(1, 2, 1, 53, "ISO week", setISOWeek)),
 	},
 	"w": {
 		Desc:   "Day of week number (0-6) where Sunday is 0 and Saturday is 6",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.Weekday())) },
+		parse:  strptimeWeekday,
 	},
 	"W": {
 		Desc: "Week number of the year where the first Monday of January is considered week 1 - (00-53)",
@@ -280,39 +359,35 @@ var tokenMapStrftime = TokenMap{
 			weekDiff := int((midnight.Sub(firstSundayJan).Hours() / 24 / 7) + 1)
 			return fmt.Sprintf("%02d", weekDiff)
 		},
+		parse: strptimeMondayWeek,
 	},
 	"x": {
 		Desc: "Locale date format - '04/12/1999', '11/02/2007'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtDateShort(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtDateShort(dt)
+		}),
 	},
 	"X": {
 		Desc: "Locale time including seconds - '03:57:22', '18:08:01'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtTimeMedium(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtTimeMedium(dt)
+		}),
 	},
 	"y": {
 		Desc:   "The year within the century zero padded to two digits (00-99)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Year()%100) },
-		parse: func(dt *time.Time, str *string) (int, error) {
-			year, err := strconv.Atoi((*str)[:2])
-			if err != nil {
-				return 0, fmt.Errorf("Unable to parse year")
-			}
-			if year > time.Now().Year()%100 {
-				year += 1900
-			} else {
-				year += 2000
-			}
-			*dt = time.Date(year, dt.Month(), dt.Day(), dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), dt.Location())
-			return 1, nil
-		},
+		parse:  strptimeTwoDigitYear,
 	},
 	"Y": {
 		Desc:   "Year number - '1999', '2007'",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Year()) },
+		parse:  strptimeYear,
 	},
 	"z": {
 		Desc: "Time zone offset in +hhmm format - '-0400', '+0530'",
@@ -322,6 +397,7 @@ var tokenMapStrftime = TokenMap{
 			offsetHours := offsetMinutes / 60
 			return fmt.Sprintf("%+03d%02d", offsetHours, offsetMinutes%60)
 		},
+		parse: spacePadded(parseOffset),
 	},
 	"Z": {
 		Desc: "Abbreviated time zone offset - 'GMT', 'CEST', '+0530'",
@@ -329,46 +405,58 @@ var tokenMapStrftime = TokenMap{
 			offsetName, _ := dt.Zone()
 			return offsetName
 		},
+		parse: parseZoneAbbreviation,
 	},
 	"Ec": {
 		Desc:   "Alternative representation for date and time for the current locale (hardcoded to UK format currently)",
 		expand: func(dt time.Time, locale locales.Translator) string { return dt.Format("Mon _2 Jan 15:04:05 2006") },
+		parse:  strptimeLayout("%a %e %b %H:%M:%S %Y"),
 	},
 	"EC": {
 		Desc: "Base year/period - (0-99)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return strconv.Itoa(dt.Year() / 100)
 		},
+		parse: strptimeCentury,
 	},
 	"Ex": {
 		Desc: "Short date format in the specified locale",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtDateShort(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtDateShort(dt)
+		}),
 	},
 	"EX": {
 		Desc: "Time format in the specified locale",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtTimeMedium(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtTimeMedium(dt)
+		}),
 	},
 	"Ey": {
 		Desc: "Year to two digits - '97', '07'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d", dt.Year()%100)
 		},
+		parse: strptimeTwoDigitYear,
 	},
 	"EY": {
 		Desc: "Alternative year number - '1997', '2007'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return strconv.Itoa(dt.Year())
 		},
+		parse: strptimeYear,
 	},
 	"Od": {
 		Desc: "Day of the month using the locale's alternative numeric symbols, zero padded - (00-31)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d", dt.Day())
 		},
+		parse: strptimeDay,
 	},
 	"Oe": {
 		Desc: "Day of the month using the locale's alternative numeric symbols, space padded - ( 0-31)",
@@ -379,12 +467,14 @@ var tokenMapStrftime = TokenMap{
 			}
 			return fmt.Sprintf(fmtstr, dt.Day())
 		},
+		parse: strptimeDay,
 	},
 	"OH": {
 		Desc: "Hour in 24 hour format using the locale's alternative numeric symbols, zero padded - (00-23)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d", dt.Hour())
 		},
+		parse: strptimeHour24,
 	},
 	"OI": {
 		Desc: "Hour in 12 hour format using the locale's alternative numeric symbols, zero padded - (00-12)",
@@ -395,20 +485,24 @@ var tokenMapStrftime = TokenMap{
 			}
 			return fmt.Sprintf("%02d", hour)
 		},
+		parse: strptimeHour12,
 	},
 	"Om": {
 		Desc: "Month number using the locale's alternative numeric symbols, zero padded (01-12)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d", dt.Month())
 		},
+		parse: strptimeMonth,
 	},
 	"OM": {
 		Desc:   "Minutes using the locale's alternative numeric symbols, zero padded (00-59)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Minute()) },
+		parse:  strptimeMinute,
 	},
 	"OS": {
 		Desc:   "Seconds using the locale's alternative numeric symbols, zero padded (00-60; 60 may occur for for leap seconds)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Second()) },
+		parse:  strptimeSecond,
 	},
 	"OU": {
 		Desc: "Week number of the year using the locale's alternative numeric symbols where the first Sunday of January is considered week 1 - (00-53)",
@@ -420,10 +514,12 @@ var tokenMapStrftime = TokenMap{
 			weekDiff := int((midnight.Sub(firstSundayJan).Hours() / 24 / 7) + 1)
 			return fmt.Sprintf("%02d", weekDiff)
 		},
+		parse: strptimeSundayWeek,
 	},
 	"Ow": {
 		Desc:   "Day of week number (0-6) using the locale's alternative numeric symbols where Sunday is 0 and Saturday is 6",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.Weekday())) },
+		parse:  strptimeWeekday,
 	},
 	"OW": {
 		Desc: "Week number of the year using the locale's alternative numeric symbols where the first Monday of January is considered week 1 - (00-53)",
@@ -435,11 +531,13 @@ var tokenMapStrftime = TokenMap{
 			weekDiff := int((midnight.Sub(firstSundayJan).Hours() / 24 / 7) + 1)
 			return fmt.Sprintf("%02d", weekDiff)
 		},
+		parse: strptimeMondayWeek,
 	},
 	"Oy": {
 		Desc: "Year number offset from the century using the locale's alternative numeric symbols - '99', '07'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d", dt.Year()%100)
 		},
+		parse: strptimeTwoDigitYear,
 	},
 }
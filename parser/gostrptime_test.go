@@ -3,6 +3,8 @@ package parser
 import (
 	"testing"
 	"time"
+
+	"github.com/go-playground/locales/en_GB"
 )
 
 type testCase struct {
@@ -15,12 +17,38 @@ func TestParse(t *testing.T) {
 	scenarios := []testCase{
 		{input: "04/01/97", format: "%d/%m/%y", want: time.Date(1997, 1, 4, 0, 0, 0, 0, time.Local)},
 		{input: " 4/01/97", format: "%e/%m/%y", want: time.Date(1997, 1, 4, 0, 0, 0, 0, time.Local)},
+		{input: "04/01/68", format: "%d/%m/%y", want: time.Date(2068, 1, 4, 0, 0, 0, 0, time.Local)},
+		{input: "04/01/69", format: "%d/%m/%y", want: time.Date(1969, 1, 4, 0, 0, 0, 0, time.Local)},
+		{input: "Saturday, 4 January 1997 15:09:02", format: "%A, %e %B %Y %H:%M:%S", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.Local)},
+		{input: "sat jan  4 03:09:02 pm 1997", format: "%a %b %e %I:%M:%S %p %Y", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.Local)},
+		{input: "Sat  4 Jan 15:09:02 1997", format: "%c", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.Local)},
+		{input: "01/04/97 15:09", format: "%D %R", want: time.Date(1997, 1, 4, 15, 9, 0, 0, time.Local)},
+		{input: "1997-01-04T15:09:02+0530", format: "%FT%T%z", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.FixedZone("", 19800))},
+		{input: "1997-01-04 15:09:02 -08:00", format: "%F %T %z", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.FixedZone("", -28800))},
+		{input: "1997-01-04 15:09:02 UTC", format: "%F %T %Z", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.UTC)},
+		{input: "03:09:02 p.m.", format: "%r", want: time.Date(1970, 1, 1, 15, 9, 2, 0, time.Local)},
+		{input: "12:00:00 a.m.", format: "%r", want: time.Date(1970, 1, 1, 0, 0, 0, 0, time.Local)},
+		{input: " 4-Jan-1997", format: "%v", want: time.Date(1997, 1, 4, 0, 0, 0, 0, time.Local)},
+		{input: "1997 004", format: "%Y %j", want: time.Date(1997, 1, 4, 0, 0, 0, 0, time.Local)},
+		{input: "19 97", format: "%C %y", want: time.Date(1997, 1, 1, 0, 0, 0, 0, time.Local)},
+		{input: "852390542", format: "%s", want: time.Unix(852390542, 0)},
+		{input: "2024 00 6", format: "%Y %U %w", want: time.Date(2024, 1, 6, 0, 0, 0, 0, time.Local)},
+		{input: "2024 01 0", format: "%Y %U %w", want: time.Date(2024, 1, 7, 0, 0, 0, 0, time.Local)},
+		{input: "2024 01 1", format: "%Y %W %u", want: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)},
+		{input: "2024 52 7", format: "%Y %W %u", want: time.Date(2024, 12, 29, 0, 0, 0, 0, time.Local)},
+		{input: "2020-W53-5", format: "%G-W%V-%u", want: time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)},
+		{input: "20-W01-1", format: "%g-W%V-%u", want: time.Date(2019, 12, 30, 0, 0, 0, 0, time.Local)},
+		{input: "100%\t\n 15", format: "100%%%n%t%H", want: time.Date(1970, 1, 1, 15, 0, 0, 0, time.Local)},
+		{input: "1997-01-04", format: "%Y - %m - %d", want: time.Date(1997, 1, 4, 0, 0, 0, 0, time.Local)},
+		{input: "04/01/1997 15:09:02", format: "%x %X", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.Local)},
+		{input: "04/01/97 15:09:02", format: "%Od/%Om/%Oy %OH:%OM:%OS", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.Local)},
+		{input: "Sat  4 Jan 15:09:02 1997 19 97", format: "%Ec %EC %Ey", want: time.Date(1997, 1, 4, 15, 9, 2, 0, time.Local)},
 	}
 
 	for _, testCase := range scenarios {
 		t.Run("", func(t *testing.T) {
 			t.Parallel()
-			got, err := GoStrptime.Parse(testCase.input, testCase.format)
+			got, err := GoStrptime.Parse(testCase.input, testCase.format, en_GB.New())
 			if err != nil {
 				t.Errorf("Failed to parse: '%s' with format '%s'\n%s", testCase.input, testCase.format, err)
 				t.Fail()
@@ -33,3 +61,85 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseInvalid(t *testing.T) {
+	scenarios := []testCase{
+		{input: "31/02/97", format: "%d/%m/%y"},
+		{input: "04/13/97", format: "%d/%m/%y"},
+		{input: "25:00", format: "%H:%M"},
+		{input: "1997-01-04 extra", format: "%F"},
+		{input: "1997-01-04", format: "%F %Q"},
+		{input: "Smarch", format: "%B"},
+		{input: "1997-01-04 15:00 XYZT", format: "%F %H:%M %Z"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := GoStrptime.Parse(testCase.input, testCase.format, en_GB.New())
+			if err == nil {
+				t.Errorf("Expected '%s' to fail parsing with format '%s'\nGot: %s", testCase.input, testCase.format, got)
+			}
+		})
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	tokens := GoStrptime.TokenMapExpanded()
+	dt := time.Date(1997, 1, 4, 15, 9, 2, 0, time.Local)
+
+	for token, tokenDef := range tokens {
+		if tokenDef.parse == nil {
+			t.Errorf("Token %q does not support parsing", token)
+		}
+	}
+
+	for _, testCase := range []testCase{
+		{format: "%c", want: dt},
+		{format: "%Ec", want: dt},
+		{format: "%D %T", want: dt},
+		{format: "%F %r", want: dt},
+		{format: "%s", want: dt},
+		{format: "%A %B %d %l %OM %OS %p %Y", want: dt},
+		{format: "%C%y %U %a %k:%M:%S", want: dt},
+		{format: "%Y %j %I %p %M", want: dt.Truncate(time.Minute)},
+		{format: "%G-W%V-%u %H", want: dt.Truncate(time.Hour)},
+		{format: "%-d/%-m/%y %_H:%0M %^a %#b", want: dt.Truncate(time.Minute)},
+	} {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			formatted := GoStrptime.Format(testCase.want, en_GB.New(), &testCase.format)
+			got, err := GoStrptime.Parse(formatted, testCase.format, en_GB.New())
+			if err != nil {
+				t.Errorf("Failed to parse: '%s' with format '%s'\n%s", formatted, testCase.format, err)
+				return
+			}
+			if got.Compare(testCase.want) != 0 {
+				t.Errorf("Fail for format '%s'\nGot:  %s\nwant: %s", testCase.format, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestFormatFlags(t *testing.T) {
+	dt := time.Date(1997, 1, 4, 9, 0, 2, 0, time.UTC)
+	scenarios := []struct {
+		format string
+		want   string
+	}{
+		{format: "%-d/%-m/%Y", want: "4/1/1997"},
+		{format: "%_H:%M %-S", want: " 9:00 2"},
+		{format: "%0e %-M", want: "04 0"},
+		{format: "%^a %#B %#Z", want: "SAT JANUARY utc"},
+		{format: "%-Q %-%", want: "-Q %"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := GoStrptime.Format(dt, en_GB.New(), &testCase.format); got != testCase.want {
+				t.Errorf("Fail formatting %q\nGot:  %s\nwant: %s", testCase.format, got, testCase.want)
+			}
+		})
+	}
+}
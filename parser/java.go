@@ -0,0 +1,602 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gitlab.com/monokuro/era/dateutils"
+
+	"github.com/go-playground/locales"
+)
+
+// Handler for parsing and formatting using the `java.time.format.DateTimeFormatter`
+// pattern letters, which `SimpleDateFormat` mostly shares
+//
+// Literal text is escaped within single quotes where two single quotes stand for one
+// and sections within square brackets are optional when parsing
+var Java DateHandlerString
+
+func init() {
+	mapExpanded := expandTokenMap(&tokenMapJava)
+	Java = DateHandlerString{
+		escapeChars:     []rune{'\''},
+		doubledEscape:   true,
+		optionalChars:   []rune{'[', ']'},
+		reservedLetters: true,
+		tokenDef:        tokenMapJava,
+		tokenGraph:      createTokenGraph(&mapExpanded),
+	}
+}
+
+// TODO: missing tokens:
+// - "W", "F" - week of month fields
+// - "zzzz", "v", "vvvv" - time zone names e.g. Pacific Standard Time
+// - "B" - period of day e.g. in the morning
+
+var tokenMapJava = TokenMap{
+	"a": {
+		Desc: "Meridiem capitalised - 'AM', 'PM'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			if dt.Hour() < 12 {
+				return "AM"
+			}
+			return "PM"
+		},
+		parse: parseMeridiem,
+	},
+	"A": {
+		Desc: "Milliseconds of the day (0-86399999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return strconv.Itoa(int(dt.Sub(dateutils.DayStart(dt)).Milliseconds()))
+		},
+		parse: numberParser(1, 8, 0, 86_399_999, "millisecond of day", func(state *parseState, millis int) {
+			state.hour = millis / 3_600_000
+			state.minute = millis / 60_000 % 60
+			state.second = millis / 1_000 % 60
+			setMillisecond(state, millis%1_000)
+		}),
+	},
+	"c": {
+		Desc: "Day of week where Sunday = 1 and Saturday = 7 (1-7)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return strconv.Itoa(int(dt.Weekday()) + 1)
+		},
+		parse:   numberParser(1, 1, 1, 7, "day of week", javaSetWeekday),
+		aliases: []string{"e"},
+	},
+	"d": {
+		Desc:   "Day of month (1-31)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Day()) },
+		parse:  numberParser(1, 2, 1, 31, "day of month", setDay),
+	},
+	"dd": {
+		Desc:   "Day of month zero padded to two digits (01-31)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Day()) },
+		parse:  numberParser(2, 2, 1, 31, "day of month", setDay),
+	},
+	"D": {
+		Desc:   "Day of year (1-366)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.YearDay()) },
+		parse:  numberParser(1, 3, 1, 366, "day of year", setYearDay),
+	},
+	"DD": {
+		Desc:   "Day of year zero padded to at least two digits (01-366)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.YearDay()) },
+		parse:  numberParser(2, 3, 1, 366, "day of year", setYearDay),
+	},
+	"DDD": {
+		Desc:   "Day of year zero padded to three digits (001-366)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%03d", dt.YearDay()) },
+		parse:  numberParser(3, 3, 1, 366, "day of year", setYearDay),
+	},
+	"E": {
+		Desc: "Abbreviated day of week name - 'Sun', 'Mon'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return locale.WeekdayAbbreviated(dt.Weekday())
+		},
+		parse:   parseWeekdayName,
+		aliases: []string{"EE", "EEE"},
+	},
+	"EEEE": {
+		Desc: "Day of week name - 'Sunday', 'Monday'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return locale.WeekdayWide(dt.Weekday())
+		},
+		parse: parseWeekdayName,
+	},
+	"EEEEE": {
+		Desc: "Day of week name truncated to one character - 'S', 'M'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return locale.WeekdayNarrow(dt.Weekday())
+		},
+	},
+	"ee": {
+		Desc: "Day of week where Sunday = 1 and Saturday = 7 zero padded to two digits (01-07)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d", int(dt.Weekday())+1)
+		},
+		parse: numberParser(2, 2, 1, 7, "day of week", javaSetWeekday),
+	},
+	"G": {
+		Desc: "Era name abbreviated - 'BC', 'AD'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			if dt.Year() < 1 {
+				return "BC"
+			}
+			return "AD"
+		},
+		parse:   parseEra,
+		aliases: []string{"GG", "GGG"},
+	},
+	"GGGG": {
+		Desc: "Era name - 'Before Christ', 'Anno Domini'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			if dt.Year() < 1 {
+				return "Before Christ"
+			}
+			return "Anno Domini"
+		},
+		parse: parseEra,
+	},
+	"GGGGG": {
+		Desc: "Era name abbreviated to one character - 'B', 'A'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			if dt.Year() < 1 {
+				return "B"
+			}
+			return "A"
+		},
+		parse: parseEra,
+	},
+	"h": {
+		Desc: "Hour in 12 hour format (1-12)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return strconv.Itoa(clockHour(dt.Hour()%12, 12))
+		},
+		parse: numberParser(1, 2, 1, 12, "hour", setHour),
+	},
+	"hh": {
+		Desc: "Hour in 12 hour format zero padded to two digits (01-12)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d", clockHour(dt.Hour()%12, 12))
+		},
+		parse: numberParser(2, 2, 1, 12, "hour", setHour),
+	},
+	"H": {
+		Desc:   "Hour in 24 hour format (0-23)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Hour()) },
+		parse:  numberParser(1, 2, 0, 23, "hour", setHour),
+	},
+	"HH": {
+		Desc:   "Hour in 24 hour format zero padded to two digits (00-23)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Hour()) },
+		parse:  numberParser(2, 2, 0, 23, "hour", setHour),
+	},
+	"k": {
+		Desc: "Hour in 24 hour format starting from 1 (1-24)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return strconv.Itoa(clockHour(dt.Hour(), 24))
+		},
+		parse: numberParser(1, 2, 1, 24, "hour", setClockHour),
+	},
+	"kk": {
+		Desc: "Hour in 24 hour format starting from 1 zero padded to two digits (01-24)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d", clockHour(dt.Hour(), 24))
+		},
+		parse: numberParser(2, 2, 1, 24, "hour", setClockHour),
+	},
+	"K": {
+		Desc:   "Hour in 12 hour format starting from 0 (0-11)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Hour() % 12) },
+		parse:  numberParser(1, 2, 0, 11, "hour", setHour),
+	},
+	"KK": {
+		Desc:   "Hour in 12 hour format starting from 0 zero padded to two digits (00-11)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Hour()%12) },
+		parse:  numberParser(2, 2, 0, 11, "hour", setHour),
+	},
+	"m": {
+		Desc:   "Minutes (0-59)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Minute()) },
+		parse:  numberParser(1, 2, 0, 59, "minute", setMinute),
+	},
+	"mm": {
+		Desc:   "Minutes zero padded to two digits (00-59)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Minute()) },
+		parse:  numberParser(2, 2, 0, 59, "minute", setMinute),
+	},
+	"M": {
+		Desc:    "Month number (1-12)",
+		expand:  func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.Month())) },
+		parse:   numberParser(1, 2, 1, 12, "month", setMonth),
+		aliases: []string{"L"},
+	},
+	"MM": {
+		Desc:    "Month number zero padded to two digits (01-12)",
+		expand:  func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Month()) },
+		parse:   numberParser(2, 2, 1, 12, "month", setMonth),
+		aliases: []string{"LL"},
+	},
+	"MMM": {
+		Desc: "Abbreviated month name - 'Jan', 'Feb'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return locale.MonthAbbreviated(dt.Month())
+		},
+		parse:   parseMonthName,
+		aliases: []string{"LLL"},
+	},
+	"MMMM": {
+		Desc: "Month name - 'January', 'February'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return locale.MonthWide(dt.Month())
+		},
+		parse:   parseMonthName,
+		aliases: []string{"LLLL"},
+	},
+	"MMMMM": {
+		Desc: "Month name truncated to one character - 'J', 'F'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return locale.MonthNarrow(dt.Month())
+		},
+		aliases: []string{"LLLLL"},
+	},
+	"n": {
+		Desc:   "Nanoseconds of the second (0-999999999)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Nanosecond()) },
+		parse: numberParser(1, 9, 0, 999_999_999, "nanosecond", func(state *parseState, nano int) {
+			state.nano = nano
+		}),
+	},
+	"O": {
+		Desc:   "Localised time zone offset shortened where possible - 'GMT', 'GMT+8', 'GMT-3:30'",
+		expand: func(dt time.Time, locale locales.Translator) string { return javaLocalisedOffset(dt, false) },
+		parse:  parseLocalisedOffset,
+	},
+	"OOOO": {
+		Desc:    "Localised time zone offset - 'GMT', 'GMT+08:00', 'GMT-03:30'",
+		expand:  func(dt time.Time, locale locales.Translator) string { return javaLocalisedOffset(dt, true) },
+		parse:   parseLocalisedOffset,
+		aliases: []string{"ZZZZ"},
+	},
+	"Q": {
+		Desc: "Quarter of year (1-4)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return strconv.Itoa(dateutils.YearQuarter(dt))
+		},
+		parse:   numberParser(1, 1, 1, 4, "quarter", setQuarter),
+		aliases: []string{"q"},
+	},
+	"QQ": {
+		Desc: "Quarter of year zero padded to two digits (01-04)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d", dateutils.YearQuarter(dt))
+		},
+		parse:   numberParser(2, 2, 1, 4, "quarter", setQuarter),
+		aliases: []string{"qq"},
+	},
+	"QQQ": {
+		Desc: "Abbreviated quarter of year - 'Q1', 'Q4'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("Q%d", dateutils.YearQuarter(dt))
+		},
+		parse: func(state *parseState, input string) (int, error) {
+			if !hasPrefixFold(input, "Q") {
+				return 0, fmt.Errorf("Unable to parse quarter: expected 'Q'")
+			}
+			consumed, err := numberParser(1, 1, 1, 4, "quarter", setQuarter)(state, input[1:])
+			return consumed + 1, err
+		},
+		aliases: []string{"qqq"},
+	},
+	"QQQQ": {
+		Desc: "Quarter of year - '1st quarter', '4th quarter'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return numberSuffixed(dateutils.YearQuarter(dt)) + " quarter"
+		},
+		parse: func(state *parseState, input string) (int, error) {
+			consumed, err := ordinalParser(1, 1, 4, "quarter", setQuarter)(state, input)
+			if err != nil {
+				return 0, err
+			}
+			if !hasPrefixFold(input[consumed:], " quarter") {
+				return 0, fmt.Errorf("Unable to parse quarter: expected ' quarter'")
+			}
+			return consumed + len(" quarter"), nil
+		},
+		aliases: []string{"qqqq"},
+	},
+	"s": {
+		Desc:   "Seconds (0-59)",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Second()) },
+		parse:  numberParser(1, 2, 0, 59, "second", setSecond),
+	},
+	"ss": {
+		Desc:   "Seconds zero padded to two digits (00-59)",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Second()) },
+		parse:  numberParser(2, 2, 0, 59, "second", setSecond),
+	},
+	"S": {
+		Desc: "Fractional seconds to one digit (0-9)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return strconv.Itoa(dt.Nanosecond() / 100_000_000)
+		},
+		parse: fractionParser(1),
+	},
+	"SS": {
+		Desc: "Fractional seconds to two digits (00-99)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d", dt.Nanosecond()/10_000_000)
+		},
+		parse: fractionParser(2),
+	},
+	"SSS": {
+		Desc: "Fractional seconds to three digits (000-999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%03d", dt.Nanosecond()/1_000_000)
+		},
+		parse: fractionParser(3),
+	},
+	"SSSS": {
+		Desc: "Fractional seconds to four digits (0000-9999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%04d", dt.Nanosecond()/100_000)
+		},
+		parse: fractionParser(4),
+	},
+	"SSSSS": {
+		Desc: "Fractional seconds to five digits (00000-99999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%05d", dt.Nanosecond()/10_000)
+		},
+		parse: fractionParser(5),
+	},
+	"SSSSSS": {
+		Desc: "Fractional seconds to six digits (000000-999999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%06d", dt.Nanosecond()/1_000)
+		},
+		parse: fractionParser(6),
+	},
+	"SSSSSSS": {
+		Desc: "Fractional seconds to seven digits (0000000-9999999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%07d", dt.Nanosecond()/100)
+		},
+		parse: fractionParser(7),
+	},
+	"SSSSSSSS": {
+		Desc: "Fractional seconds to eight digits (00000000-99999999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%08d", dt.Nanosecond()/10)
+		},
+		parse: fractionParser(8),
+	},
+	"SSSSSSSSS": {
+		Desc: "Fractional seconds to nine digits (000000000-999999999)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%09d", dt.Nanosecond())
+		},
+		parse: fractionParser(9),
+	},
+	"u": {
+		Desc:   "Year number which is negative before 1 AD - '1999', '-44'",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Year()) },
+		parse:  yearParser(1, 9),
+	},
+	"uu": {
+		Desc: "Year number truncated to last two digits - '99', '07'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d", javaAbs(dt.Year())%100)
+		},
+		parse: numberParser(2, 2, 0, 99, "year", func(state *parseState, year int) {
+			setYear(state, expandTwoDigitYear(year, 99))
+		}),
+	},
+	"uuuu": {
+		Desc:   "Year number zero padded to four digits which is negative before 1 AD - '1999', '-0044'",
+		expand: func(dt time.Time, locale locales.Translator) string { return javaPaddedYear(dt.Year(), 4) },
+		parse:  yearParser(4, 9),
+	},
+	"VV": {
+		Desc: "IANA time zone ID - 'Europe/London'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return dt.Location().String()
+		},
+		parse: parseZoneName,
+	},
+	"w": {
+		Desc: "Week of the week based year where weeks start on Sunday and week one contains January 1st (1-53)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			_, week := javaLocaleWeek(dt)
+			return strconv.Itoa(week)
+		},
+		parse: numberParser(1, 2, 1, 53, "week", setWeek),
+	},
+	"ww": {
+		Desc: "Week of the week based year where weeks start on Sunday and week one contains January 1st zero padded to two digits (01-53)",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			_, week := javaLocaleWeek(dt)
+			return fmt.Sprintf("%02d", week)
+		},
+		parse: numberParser(2, 2, 1, 53, "week", setWeek),
+	},
+	"X": {
+		Desc:   "Time zone offset with minutes when non-zero or 'Z' for UTC - 'Z', '+08', '-0330'",
+		expand: func(dt time.Time, locale locales.Translator) string { return formatOffset(dt, "", false, "Z") },
+		parse:  parseOffset,
+	},
+	"XX": {
+		Desc:   "Time zone offset or 'Z' for UTC - 'Z', '+0800', '-0330'",
+		expand: func(dt time.Time, locale locales.Translator) string { return formatOffset(dt, "", true, "Z") },
+		parse:  parseOffset,
+	},
+	"XXX": {
+		Desc:    "Time zone offset separated by ':' or 'Z' for UTC - 'Z', '+08:00', '-03:30'",
+		expand:  func(dt time.Time, locale locales.Translator) string { return formatOffset(dt, ":", true, "Z") },
+		parse:   parseOffset,
+		aliases: []string{"ZZZZZ"},
+	},
+	"x": {
+		Desc:   "Time zone offset with minutes when non-zero - '+00', '+08', '-0330'",
+		expand: func(dt time.Time, locale locales.Translator) string { return formatOffset(dt, "", false, "") },
+		parse:  parseOffset,
+	},
+	"xx": {
+		Desc:    "Time zone offset - '+0000', '+0800', '-0330'",
+		expand:  func(dt time.Time, locale locales.Translator) string { return formatOffset(dt, "", true, "") },
+		parse:   parseOffset,
+		aliases: []string{"Z", "ZZ", "ZZZ"},
+	},
+	"xxx": {
+		Desc:   "Time zone offset separated by ':' - '+00:00', '+08:00', '-03:30'",
+		expand: func(dt time.Time, locale locales.Translator) string { return formatOffset(dt, ":", true, "") },
+		parse:  parseOffset,
+	},
+	"y": {
+		Desc:   "Year of the era - '1999', '45'",
+		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(javaYearOfEra(dt.Year())) },
+		parse:  yearParser(1, 9),
+	},
+	"yy": {
+		Desc: "Year of the era truncated to last two digits - '99', '07'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d", javaYearOfEra(dt.Year())%100)
+		},
+		parse: numberParser(2, 2, 0, 99, "year", func(state *parseState, year int) {
+			setYear(state, expandTwoDigitYear(year, 99))
+		}),
+	},
+	"yyy": {
+		Desc: "Year of the era zero padded to three digits - '1999', '045'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return javaPaddedYear(javaYearOfEra(dt.Year()), 3)
+		},
+		parse: yearParser(3, 9),
+	},
+	"yyyy": {
+		Desc: "Year of the era zero padded to four digits - '1999', '0045'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			return javaPaddedYear(javaYearOfEra(dt.Year()), 4)
+		},
+		parse: yearParser(4, 9),
+	},
+	"Y": {
+		Desc: "Week based year where weeks start on Sunday and week one contains January 1st - '1999', '45'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			year, _ := javaLocaleWeek(dt)
+			return strconv.Itoa(year)
+		},
+		parse: numberParser(1, 9, 0, 999_999_999, "week year", setWeekYear),
+	},
+	"YY": {
+		Desc: "Week based year truncated to last two digits - '99', '07'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			year, _ := javaLocaleWeek(dt)
+			return fmt.Sprintf("%02d", javaAbs(year)%100)
+		},
+		parse: numberParser(2, 2, 0, 99, "week year", func(state *parseState, year int) {
+			setWeekYear(state, expandTwoDigitYear(year, 99))
+		}),
+	},
+	"YYYY": {
+		Desc: "Week based year zero padded to four digits - '1999', '0045'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			year, _ := javaLocaleWeek(dt)
+			return javaPaddedYear(year, 4)
+		},
+		parse: numberParser(4, 9, 0, 999_999_999, "week year", setWeekYear),
+	},
+	"z": {
+		Desc: "Abbreviated time zone name - 'GMT', 'CEST'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			offsetName, _ := dt.Zone()
+			return offsetName
+		},
+		parse:   parseZoneAbbreviation,
+		aliases: []string{"zz", "zzz"},
+	},
+}
+
+// Sets the day of week counting from Sunday = 1
+func javaSetWeekday(state *parseState, weekday int) {
+	setWeekday(state, weekday-1)
+}
+
+// Week based year and week of the date time as in the US where weeks start on Sunday
+// and week one contains January 1st, the same as moment's 'gggg' and 'w'
+func javaLocaleWeek(dt time.Time) (int, int) {
+	sunday := time.Date(dt.Year(), dt.Month(), dt.Day()-int(dt.Weekday()), 0, 0, 0, 0, time.UTC)
+	// The week belongs to the year its Saturday is in
+	year := sunday.AddDate(0, 0, 6).Year()
+	return year, int(sunday.Sub(localeWeekDate(year, 1, time.Sunday)).Hours())/24/7 + 1
+}
+
+// Sets the hour of a clock counting from 1 to 24 where 24 is midnight
+func setClockHour(state *parseState, hour int) {
+	setHour(state, hour%24)
+}
+
+// Year counted from 1 within its era so 1 BC follows 1 AD
+func javaYearOfEra(year int) int {
+	if year < 1 {
+		return 1 - year
+	}
+	return year
+}
+
+func javaAbs(num int) int {
+	if num < 0 {
+		return -num
+	}
+	return num
+}
+
+// Year zero padded to `width` digits with the sign placed before the padding
+func javaPaddedYear(year, width int) string {
+	if year < 0 {
+		return fmt.Sprintf("-%0*d", width, -year)
+	}
+	return fmt.Sprintf("%0*d", width, year)
+}
+
+// Formats the time zone offset of the date time relative to GMT e.g. 'GMT+8' or when
+// `full` is set 'GMT+08:00'
+func javaLocalisedOffset(dt time.Time, full bool) string {
+	_, offset := dt.Zone()
+	if offset == 0 {
+		return "GMT"
+	}
+
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	hours, mins := offset/3600, offset/60%60
+	switch {
+	case full:
+		return fmt.Sprintf("GMT%c%02d:%02d", sign, hours, mins)
+	case mins == 0:
+		return fmt.Sprintf("GMT%c%d", sign, hours)
+	}
+	return fmt.Sprintf("GMT%c%d:%02d", sign, hours, mins)
+}
+
+// Parses a time zone offset relative to GMT or UTC such as 'GMT', 'GMT+8' or
+// 'UTC-03:30'
+func parseLocalisedOffset(state *parseState, input string) (int, error) {
+	_, consumed, ok := parseName(input, []string{"GMT", "UTC", "UT"})
+	if !ok {
+		return 0, fmt.Errorf("Unable to parse time zone offset: expected 'GMT'")
+	}
+
+	rest := input[consumed:]
+	if len(rest) == 0 || (rest[0] != '+' && rest[0] != '-') {
+		state.location = time.UTC
+		return consumed, nil
+	}
+	offsetConsumed, err := parseOffset(state, rest)
+	return consumed + offsetConsumed, err
+}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/locales/en_GB"
+)
+
+func TestFormatJava(t *testing.T) {
+	kolkata := time.FixedZone("IST", 19800)
+	stJohns := time.FixedZone("NDT", -9000)
+	scenarios := []struct {
+		dt     time.Time
+		format string
+		want   string
+	}{
+		{dt: time.Date(2024, 1, 7, 23, 59, 59, 123_456_789, kolkata), format: "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", want: "2024-01-07T23:59:59.123+05:30"},
+		{dt: time.Date(2024, 1, 7, 23, 59, 59, 0, time.UTC), format: "yyyy-MM-dd'T'HH:mm:ssXXX", want: "2024-01-07T23:59:59Z"},
+		{dt: time.Date(2024, 1, 7, 23, 59, 59, 0, time.UTC), format: "uuuu-MM-dd HH:mm:ss xxx", want: "2024-01-07 23:59:59 +00:00"},
+		{dt: time.Date(1997, 1, 4, 0, 5, 0, 0, stJohns), format: "EEE, d MMM yy hh:mm a", want: "Sat, 4 Jan 97 12:05 AM"},
+		{dt: time.Date(1997, 1, 4, 0, 5, 0, 0, stJohns), format: "EEEE MMMM kk:mm O OOOO X Z", want: "Saturday January 24:05 GMT-2:30 GMT-02:30 -0230 -0230"},
+		{dt: time.Date(1997, 1, 4, 0, 5, 0, 0, kolkata), format: "'o''clock' '' h", want: "o'clock ' 12"},
+		{dt: time.Date(1997, 1, 4, 0, 5, 0, 0, kolkata), format: "HH:mm[:ss[.SSS]]", want: "00:05:00.000"},
+		{dt: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), format: "D DDD QQQ QQQQ G", want: "366 366 Q4 4th quarter AD"},
+		{dt: time.Date(-43, 3, 15, 0, 0, 0, 0, time.UTC), format: "y G uuuu", want: "44 BC -0043"},
+		{dt: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), format: "VV z", want: "UTC UTC"},
+		{dt: time.Date(2025, 5, 9, 0, 0, 0, 0, time.UTC), format: "YYYY-'W'ww-e Y YY w ee c", want: "2025-W19-6 2025 25 19 06 6"},
+		{dt: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), format: "YYYY-'W'ww-e", want: "2026-W01-4"},
+		{dt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), format: "YYYY-'W'ww-e", want: "2023-W01-1"},
+		{dt: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), format: "yyyy W", want: ""},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := Java.Format(testCase.dt, en_GB.New(), &testCase.format); got != testCase.want {
+				t.Errorf("Fail formatting %q\nGot:  %s\nwant: %s", testCase.format, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseJava(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	scenarios := []testCase{
+		{input: "2024-01-07T23:59:59.123+05:30", format: "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", want: time.Date(2024, 1, 7, 23, 59, 59, 123_000_000, time.FixedZone("", 19800))},
+		{input: "2024-01-07T23:59:59Z", format: "uuuu-MM-dd'T'HH:mm:ssXXX", want: time.Date(2024, 1, 7, 23, 59, 59, 0, time.UTC)},
+		{input: "2024-01-07T23:59Z", format: "yyyy-MM-dd'T'HH:mm[:ss[.SSS]]XXX", want: time.Date(2024, 1, 7, 23, 59, 0, 0, time.UTC)},
+		{input: "2024-01-07T23:59:59Z", format: "yyyy-MM-dd'T'HH:mm[:ss[.SSS]]XXX", want: time.Date(2024, 1, 7, 23, 59, 59, 0, time.UTC)},
+		{input: "Sat, 04 Jan 25 12:05 am", format: "EEE, dd MMM yy hh:mm a", want: time.Date(2025, 1, 4, 0, 5, 0, 0, time.Local)},
+		{input: "04 Jan 1997 10:00 GMT-2:30", format: "dd MMM yyyy HH:mm O", want: time.Date(1997, 1, 4, 10, 0, 0, 0, time.FixedZone("", -9000))},
+		{input: "04 Jan 1997 10:00 Europe/Paris", format: "dd MMM yyyy HH:mm VV", want: time.Date(1997, 1, 4, 10, 0, 0, 0, paris)},
+		{input: "04/01/1997 at 10 o'clock", format: "dd/MM/yyyy 'at' H 'o''clock'", want: time.Date(1997, 1, 4, 10, 0, 0, 0, time.Local)},
+		{input: "24:00 31/12/1989", format: "kk:mm dd/MM/yyyy", want: time.Date(1989, 12, 31, 0, 0, 0, 0, time.Local)},
+		{input: "Q3 1989", format: "QQQ yyyy", want: time.Date(1989, 7, 1, 0, 0, 0, 0, time.Local)},
+		{input: "2024-05", format: "yyyy[-MM]-dd", want: time.Date(2024, 1, 5, 0, 0, 0, 0, time.Local)},
+		{input: "2024", format: "yyyy" + strings.Repeat("[ y]", 40), want: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)},
+		{input: "2026-W01-4", format: "YYYY-'W'ww-e", want: time.Date(2025, 12, 31, 0, 0, 0, 0, time.Local)},
+		{input: "25 19", format: "YY w", want: time.Date(2025, 5, 4, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := Java.Parse(testCase.input, testCase.format, en_GB.New())
+			if err != nil {
+				t.Errorf("Failed to parse: '%s' with format '%s'\n%s", testCase.input, testCase.format, err)
+				return
+			}
+			if got.Compare(testCase.want) != 0 {
+				t.Errorf("Fail\nGot:  %s\nwant: %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseJavaInvalid(t *testing.T) {
+	scenarios := []testCase{
+		{input: "2024-02-30", format: "yyyy-MM-dd"},
+		{input: "2024-01-07T23", format: "yyyy-MM-dd['T'HH:mm]"},
+		{input: "2024-01-07 trailing", format: "yyyy-MM-dd"},
+		{input: "13:00 PM", format: "hh:mm a"},
+		{input: "2024 x", format: "yyyy" + strings.Repeat("[ y]", 40)},
+		{input: "2024 W", format: "yyyy W"},
+		{input: "2024-01 1", format: "yyyy-MM F"},
+		{input: "2025-W19-6", format: "YYYY-'W'ww-e-"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := Java.Parse(testCase.input, testCase.format, en_GB.New())
+			if err == nil {
+				t.Errorf("Expected '%s' to fail parsing with format '%s'\nGot: %s", testCase.input, testCase.format, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Problem found within a layout by `LintLayout`
+type LayoutProblem struct {
+	// Byte offset of the problem within the layout
+	Offset  int    `json:"offset"`
+	Raw     string `json:"raw"`
+	Message string `json:"message"`
+	// Problem only matters when the layout is used for parsing e.g. a format only token
+	ParseOnly bool `json:"parse_only,omitempty"`
+}
+
+// Checks a layout for sections the named formatter silently outputs as is or reads
+// differently than intended: unknown tokens, unterminated escapes, unescaped letters,
+// runs of a letter split into several tokens and tokens that cannot be parsed
+func LintLayout(layout, formatter string) ([]LayoutProblem, error) {
+	sections, err := LayoutSections(layout, formatter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only moment, luxon, java and .NET read runs of letters as tokens and can escape
+	// literal text
+	dialect := strings.ToLower(formatter)
+	switch dialect {
+	case ".net", "csharp":
+		dialect = "dotnet"
+	case "momentjs":
+		dialect = "moment"
+	}
+	letterTokens := slices.Contains([]string{"moment", "luxon", "java", "dotnet"}, dialect)
+	escape := "[%s]"
+	if dialect == "luxon" || dialect == "java" || dialect == "dotnet" {
+		escape = "'%s'"
+	}
+
+	problems := []LayoutProblem{}
+	add := func(offset int, raw string, parseOnly bool, message string, a ...any) {
+		problems = append(problems, LayoutProblem{Offset: offset, Raw: raw, Message: fmt.Sprintf(message, a...), ParseOnly: parseOnly})
+	}
+
+	offset := 0
+	for idx, section := range sections {
+		switch {
+		case section.Unknown:
+			add(offset, section.Raw, false, "Unknown token %q is output as is", section.Raw)
+		case section.Unterminated:
+			add(offset, section.Raw, false, "Escaped text %q is missing its closing character so runs to the end of the layout", section.Raw)
+		case section.Token && !section.Parsable:
+			add(offset, section.Raw, true, "Token %q can only be used for formatting and cannot be parsed", section.Raw)
+		case letterTokens && !section.Token && !section.Escaped:
+			if letters := strings.IndexFunc(section.Literal, unicode.IsLetter); letters != -1 {
+				add(offset+letters, section.Literal, false, "Letters in %q are not a token and are output as is, escape them as %s", section.Literal, fmt.Sprintf(escape, strings.TrimSpace(section.Literal)))
+			}
+		}
+
+		// A run of one letter longer than any token is split into several tokens
+		if letterTokens && idx > 0 && !section.Escaped && !sections[idx-1].Escaped && (section.Token || sections[idx-1].Token) {
+			prevChar, _ := utf8.DecodeLastRuneInString(sections[idx-1].Raw)
+			char, _ := utf8.DecodeRuneInString(section.Raw)
+			if prevChar == char && unicode.IsLetter(char) {
+				run := sections[idx-1].Raw + section.Raw
+				add(offset-len(sections[idx-1].Raw), run, false, "Ambiguous run %q is read as %q followed by %q", run, sections[idx-1].Raw, section.Raw)
+			}
+		}
+
+		offset += len(section.Raw)
+	}
+
+	return problems, nil
+}
@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintLayout(t *testing.T) {
+	scenarios := []struct {
+		layout    string
+		formatter string
+		want      []string
+	}{
+		{layout: "YYYY-MM-DD HH:mm:ss", formatter: "moment"},
+		{layout: "YYYY-MM-DD[T]HH:mm", formatter: "moment"},
+		{layout: "DD MMMMM YYYY", formatter: "moment", want: []string{"Ambiguous run"}},
+		{layout: "YYYY-MM-DDTHH:mm", formatter: "moment", want: []string{"Letters in"}},
+		{layout: "[at HH:mm", formatter: "moment", want: []string{"missing its closing character"}},
+		{layout: "yyyy 'W", formatter: "luxon", want: []string{"missing its closing character"}},
+		{layout: "LLLLL yyyy", formatter: "luxon", want: []string{"cannot be parsed"}},
+		{layout: "%Y-%m-%d %Q", formatter: "strftime", want: []string{"Unknown token"}},
+		{layout: "%-d", formatter: "strftime"},
+		{layout: "%_H:%M", formatter: "go:strftime"},
+		{layout: "%Ey", formatter: "strftime"},
+		{layout: "%-Q", formatter: "strftime", want: []string{"Unknown token"}},
+		{layout: "2006-01-02 15:04:05.000", formatter: "go"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.layout, func(t *testing.T) {
+			t.Parallel()
+			problems, err := LintLayout(testCase.layout, testCase.formatter)
+			if err != nil {
+				t.Fatalf("Failed to lint '%s'\n%s", testCase.layout, err)
+			}
+			if len(problems) != len(testCase.want) {
+				t.Fatalf("Expected %d problems with '%s'\nGot: %v", len(testCase.want), testCase.layout, problems)
+			}
+			for idx, problem := range problems {
+				if !strings.Contains(problem.Message, testCase.want[idx]) {
+					t.Errorf("Expected a problem containing '%s' with '%s'\nGot: %s", testCase.want[idx], testCase.layout, problem.Message)
+				}
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ func init() {
 	mapExpanded := expandTokenMap(&tokenMapLuxon)
 	Luxon = DateHandlerString{
 		escapeChars: []rune{'\''},
+		runTokens:   true,
 		tokenDef:    tokenMapLuxon,
 		tokenGraph:  createTokenGraph(&mapExpanded),
 	}
@@ -37,12 +38,14 @@ var tokenMapLuxon = TokenMap{
 			}
 			return "pm"
 		},
+		parse: parseMeridiem,
 	},
 	"c": {
 		Desc: "Day of week where Monday = 1 and Sunday = 7 (1-7)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return strconv.Itoa((int(dt.Weekday())+6)%7 + 1)
 		},
+		parse:   numberParser(1, 1, 1, 7, "day of week", setWeekday),
 		aliases: []string{"E"},
 	},
 	"ccc": {
@@ -50,6 +53,7 @@ var tokenMapLuxon = TokenMap{
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.WeekdayWide(dt.Weekday())[:3]
 		},
+		parse:   parseWeekdayName,
 		aliases: []string{"EEE"},
 	},
 	"cccc": {
@@ -57,6 +61,7 @@ var tokenMapLuxon = TokenMap{
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.WeekdayWide(dt.Weekday())
 		},
+		parse:   parseWeekdayName,
 		aliases: []string{"EEEE"},
 	},
 	"ccccc": {
@@ -69,34 +74,48 @@ var tokenMapLuxon = TokenMap{
 	"d": {
 		Desc:   "Day of month (1-31)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Day()) },
+		parse:  numberParser(1, 2, 1, 31, "day of month", setDay),
 	},
 	"dd": {
 		Desc:   "Day of month zero padded to two digits (01-31)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Day()) },
+		parse:  numberParser(2, 2, 1, 31, "day of month", setDay),
 	},
 	"D": {
 		Desc: "Localised numerical date - '08/11/24'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtDateShort(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtDateShort(dt)
+		}),
 	},
 	"DD": {
 		Desc: "Localised date with abbreviated month name - 'Nov 8, 2024'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtDateMedium(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtDateMedium(dt)
+		}),
 	},
 	"DDD": {
 		Desc: "Localised date with month name - 'November 8, 2024'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtDateLong(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtDateLong(dt)
+		}),
 	},
 	"DDDD": {
 		Desc: "Localised date with weekday and month name - 'Friday, November 8, 2024'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtDateFull(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtDateFull(dt)
+		}),
 	},
 	"G": {
 		Desc: "Era name abbreviated - 'BC', 'AD'",
@@ -107,6 +126,7 @@ var tokenMapLuxon = TokenMap{
 			}
 			return "AD"
 		},
+		parse: parseEra,
 	},
 	"GG": {
 		Desc: "Era name abbreviated - 'Before Christ', 'Anno Domini'",
@@ -117,6 +137,7 @@ var tokenMapLuxon = TokenMap{
 			}
 			return "Anno Domini"
 		},
+		parse: parseEra,
 	},
 	"GGGGG": {
 		Desc: "Era name abbreviated to one character - 'B', 'A'",
@@ -127,14 +148,17 @@ var tokenMapLuxon = TokenMap{
 			}
 			return "A"
 		},
+		parse: parseEra,
 	},
 	"H": {
 		Desc:   "Hour in 24 hour format (0-23)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Hour()) },
+		parse:  numberParser(1, 2, 0, 23, "hour", setHour),
 	},
 	"HH": {
 		Desc:   "Hour in 24 hour format zero padded to two digits (00-23)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Hour()) },
+		parse:  numberParser(2, 2, 0, 23, "hour", setHour),
 	},
 	"h": {
 		Desc: "Hour in 12 hour format (1-12)",
@@ -145,6 +169,7 @@ var tokenMapLuxon = TokenMap{
 			}
 			return strconv.Itoa(hour)
 		},
+		parse: numberParser(1, 2, 1, 12, "hour", setHour),
 	},
 	"hh": {
 		Desc: "Hour in 12 hour format zero padded to two digits (01-12)",
@@ -155,6 +180,7 @@ var tokenMapLuxon = TokenMap{
 			}
 			return fmt.Sprintf("%02d", hour)
 		},
+		parse: numberParser(2, 2, 1, 12, "hour", setHour),
 	},
 	"kk": {
 		Desc: "ISO week year shortened to the last two digits - '99, '07'",
@@ -162,6 +188,9 @@ var tokenMapLuxon = TokenMap{
 			year, _ := dt.ISOWeek()
 			return fmt.Sprintf("%02d", year%100)
 		},
+		parse: numberParser(2, 2, 0, 99, "ISO week year", func(state *parseState, year int) {
+			setISOYear(state, expandTwoDigitYear(year, 60))
+		}),
 	},
 	"kkkk": {
 		Desc: "ISO week year zero padded to four digits - '1999', '2007'",
@@ -169,15 +198,18 @@ var tokenMapLuxon = TokenMap{
 			year, _ := dt.ISOWeek()
 			return fmt.Sprintf("%04d", year)
 		},
+		parse: numberParser(4, 4, 0, 9999, "ISO week year", setISOYear),
 	},
 	"L": {
 		Desc:    "Month number (1-12)",
 		expand:  func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.Month())) },
+		parse:   numberParser(1, 2, 1, 12, "month", setMonth),
 		aliases: []string{"M"},
 	},
 	"LL": {
 		Desc:    "Month number zero padded to two digits - (01-12)",
 		expand:  func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Month()) },
+		parse:   numberParser(2, 2, 1, 12, "month", setMonth),
 		aliases: []string{"MM"},
 	},
 	"LLL": {
@@ -185,6 +217,7 @@ var tokenMapLuxon = TokenMap{
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.MonthWide(dt.Month())[:3]
 		},
+		parse:   parseMonthName,
 		aliases: []string{"MMM"},
 	},
 	"LLLL": {
@@ -192,6 +225,7 @@ var tokenMapLuxon = TokenMap{
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.MonthWide(dt.Month())
 		},
+		parse:   parseMonthName,
 		aliases: []string{"MMMM"},
 	},
 	"LLLLL": {
@@ -204,10 +238,12 @@ var tokenMapLuxon = TokenMap{
 	"m": {
 		Desc:   "Minutes (0-59)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Minute()) },
+		parse:  numberParser(1, 2, 0, 59, "minute", setMinute),
 	},
 	"mm": {
 		Desc:   "Minutes zero padded to two digits (00-59)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Minute()) },
+		parse:  numberParser(2, 2, 0, 59, "minute", setMinute),
 	},
 	"n": {
 		Desc: "Week of year where the week containing January 1st is considered week one (1-53)",
@@ -220,6 +256,7 @@ var tokenMapLuxon = TokenMap{
 			weekDiff := midnight.Sub(lastSunday).Hours() / 24 / 7
 			return strconv.Itoa(int(weekDiff)%int(weeksInYear) + 1)
 		},
+		parse: numberParser(1, 2, 1, 53, "week", setWeek),
 	},
 	"nn": {
 		Desc: "Week of year where the week containing January 1st is considered week one, zero padded to two digits (01-53)",
@@ -232,44 +269,53 @@ var tokenMapLuxon = TokenMap{
 			weekDiff := midnight.Sub(lastSunday).Hours() / 24 / 7
 			return fmt.Sprintf("%02d", int(weekDiff)%int(weeksInYear)+1)
 		},
+		parse: numberParser(2, 2, 1, 53, "week", setWeek),
 	},
 	"o": {
 		Desc:   "Ordinal day of year (1-366)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.YearDay()) },
+		parse:  numberParser(1, 3, 1, 366, "day of year", setYearDay),
 	},
 	"ooo": {
 		Desc:   "Ordinal day of year zero padded to three digits (001-366)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%03d", dt.YearDay()) },
+		parse:  numberParser(3, 3, 1, 366, "day of year", setYearDay),
 	},
 	"q": {
 		Desc: "Quarter of year (1-4)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return strconv.Itoa(dateutils.YearQuarter(dt))
 		},
+		parse: numberParser(1, 1, 1, 4, "quarter", setQuarter),
 	},
 	"qq": {
 		Desc: "Quarter of year zero padded to two digits (01-04)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d", dateutils.YearQuarter(dt))
 		},
+		parse: numberParser(2, 2, 1, 4, "quarter", setQuarter),
 	},
 	"s": {
 		Desc:   "Seconds (0-59)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Second()) },
+		parse:  numberParser(1, 2, 0, 59, "second", setSecond),
 	},
 	"ss": {
 		Desc:   "Seconds zero padded to two digits (00-59)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Second()) },
+		parse:  numberParser(2, 2, 0, 59, "second", setSecond),
 	},
 	"S": {
 		Desc:   "Milliseconds (0-999)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Nanosecond() / 1_000_000) },
+		parse:  numberParser(1, 3, 0, 999, "milliseconds", setMillisecond),
 	},
 	"SSS": {
 		Desc: "Milliseconds zero padded to three digits (000-999)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%03d", dt.Nanosecond()/1_000_000)
 		},
+		parse:   numberParser(3, 3, 0, 999, "milliseconds", setMillisecond),
 		aliases: []string{"u"},
 	},
 	"t": {
@@ -277,36 +323,54 @@ var tokenMapLuxon = TokenMap{
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtTimeShort(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtTimeShort(dt)
+		}),
 	},
 	"tt": {
 		Desc: "Localised time with seconds - '9:07:53 AM'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtTimeMedium(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtTimeMedium(dt)
+		}),
 	},
 	"ttt": {
 		Desc: "Localised time with seconds and abbreviated offset name - '9:07:53 AM EDT'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtTimeLong(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtTimeLong(dt)
+		}),
 	},
 	"tttt": {
 		Desc: "Localised time with seconds and offset name - '9:07:53 AM Eastern Daylight Time'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.FmtTimeFull(dt)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return locale.FmtTimeFull(dt)
+		}),
 	},
 	"T": {
 		Desc: "Localised 24 hour time - '13:07'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d:%02d", dt.Hour(), dt.Minute())
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d:%02d", dt.Hour(), dt.Minute())
+		}),
 	},
 	"TT": {
 		Desc: "Localised 24 hour time with seconds - '13:07'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d:%02d:%02d", dt.Hour(), dt.Minute(), dt.Second())
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			return fmt.Sprintf("%02d:%02d:%02d", dt.Hour(), dt.Minute(), dt.Second())
+		}),
 	},
 	"TTT": {
 		Desc: "Localised 24 hour time with seconds and abbreviated offset - '13:07 CST'",
@@ -314,6 +378,10 @@ var tokenMapLuxon = TokenMap{
 			offsetName, _ := dt.Zone()
 			return fmt.Sprintf("%d:%02d:%02d %s", dt.Hour(), dt.Minute(), dt.Second(), offsetName)
 		},
+		parse: localisedParser(func(dt time.Time, locale locales.Translator) string {
+			offsetName, _ := dt.Zone()
+			return fmt.Sprintf("%d:%02d:%02d %s", dt.Hour(), dt.Minute(), dt.Second(), offsetName)
+		}),
 	},
 	"W": {
 		Desc: "ISO week (1-53)",
@@ -321,6 +389,7 @@ var tokenMapLuxon = TokenMap{
 			_, week := dt.ISOWeek()
 			return strconv.Itoa(week)
 		},
+		parse:   numberParser(1, 2, 1, 53, "ISO week", setISOWeek),
 		aliases: []string{"n"},
 	},
 	"WW": {
@@ -329,6 +398,7 @@ var tokenMapLuxon = TokenMap{
 			_, week := dt.ISOWeek()
 			return fmt.Sprintf("%02d", week)
 		},
+		parse:   numberParser(2, 2, 1, 53, "ISO week", setISOWeek),
 		aliases: []string{"nn"},
 	},
 	"uu": {
@@ -336,33 +406,42 @@ var tokenMapLuxon = TokenMap{
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d", dt.Nanosecond()/10_000_000)
 		},
+		parse: fractionParser(2),
 	},
 	"uuu": {
 		Desc: "Fractional seconds between 0 and 9 (0-9)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%d", dt.Nanosecond()/100_000_000)
 		},
+		parse: fractionParser(1),
 	},
 	"X": {
 		Desc:   "Unix timestamp in seconds",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.Unix())) },
+		parse:  unixParser(time.Second),
 	},
 	"x": {
 		Desc:   "Unix timestamp in milliseconds",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.UnixMilli())) },
+		parse:  unixParser(time.Millisecond),
 	},
 	"y": {
 		Desc:   "Year number - '1999', '2007'",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Year()) },
+		parse:  yearParser(1, 6),
 	},
 	"yy": {
-		Desc:    "Year number truncated to last two digits - '99', '07'",
-		expand:  func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Year()%100) },
+		Desc:   "Year number truncated to last two digits - '99', '07'",
+		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Year()%100) },
+		parse: numberParser(2, 2, 0, 99, "year", func(state *parseState, year int) {
+			setYear(state, expandTwoDigitYear(year, 60))
+		}),
 		aliases: []string{"ii"},
 	},
 	"yyyy": {
 		Desc:    "Year number zero padded to four digits - '1999', '0007'",
 		expand:  func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%04d", dt.Year()) },
+		parse:   yearParser(4, 4),
 		aliases: []string{"iiii"},
 	},
 	"z": {
@@ -370,6 +449,7 @@ var tokenMapLuxon = TokenMap{
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return dt.Location().String()
 		},
+		parse: parseZoneName,
 	},
 	"Z": {
 		Desc: "Time zone offset shortened to one digit - '+5', '-3'",
@@ -378,6 +458,7 @@ var tokenMapLuxon = TokenMap{
 			offsetHours := offsetSeconds / (60 * 60)
 			return fmt.Sprintf("%+d", offsetHours)
 		},
+		parse: parseOffset,
 	},
 	"ZZ": {
 		Desc: "Time zone offset - '+05:30', '-03:00'",
@@ -387,6 +468,7 @@ var tokenMapLuxon = TokenMap{
 			offsetHours := offsetMinutes / 60
 			return fmt.Sprintf("%+03d:%02d", offsetHours, offsetMinutes%60)
 		},
+		parse: parseOffset,
 	},
 	"ZZZ": {
 		Desc: "Time zone offset formatted without the dividing ':' - '+0530', '-0300'",
@@ -396,6 +478,7 @@ var tokenMapLuxon = TokenMap{
 			offsetHours := offsetMinutes / 60
 			return fmt.Sprintf("%+03d%02d", offsetHours, offsetMinutes%60)
 		},
+		parse: parseOffset,
 	},
 	"ZZZZ": {
 		Desc: "Abbreviated time zone offset - 'GMT', 'CEST', '+0530'",
@@ -403,5 +486,6 @@ var tokenMapLuxon = TokenMap{
 			offsetName, _ := dt.Zone()
 			return offsetName
 		},
+		parse: parseZoneAbbreviation,
 	},
 }
@@ -6,7 +6,9 @@ import (
 
 	"github.com/go-playground/locales"
 	"github.com/go-playground/locales/en_GB"
-	// "github.com/go-playground/locales/fr_FR"
+	"github.com/go-playground/locales/en_US"
+	"github.com/go-playground/locales/es_ES"
+	"github.com/go-playground/locales/fr_FR"
 )
 
 func TestTokensLuxon(t *testing.T) {
@@ -75,3 +77,104 @@ func TestFormatStringsLuxon(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatLuxon(t *testing.T) {
+	dt := time.Date(1997, 1, 4, 15, 9, 2, 0, time.UTC)
+	scenarios := []struct {
+		format string
+		want   string
+	}{
+		{format: "yyy", want: "yyy"},
+		{format: "yyyyy", want: "yyyyy"},
+		{format: "yyyy", want: "1997"},
+		{format: "yy", want: "97"},
+		{format: "yyyyLLdd", want: "19970104"},
+		{format: "yyyy' 'yyy", want: "1997 yyy"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := Luxon.Format(dt, en_GB.New(), &testCase.format); got != testCase.want {
+				t.Errorf("Fail formatting %q\nGot:  %s\nwant: %s", testCase.format, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseLuxon(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	scenarios := []testCase{
+		{input: "Saturday, 4 January 1997 3:09 pm", format: "cccc, d LLLL yyyy h:mm a", want: time.Date(1997, 1, 4, 15, 9, 0, 0, time.Local)},
+		{input: "Sat 04 Jan 97 00:00:00.120", format: "EEE dd MMM yy HH:mm:ss.SSS", want: time.Date(1997, 1, 4, 0, 0, 0, 120_000_000, time.Local)},
+		{input: "2024-01-07T23:59:59+05:30", format: "yyyy-MM-dd'T'HH:mm:ssZZ", want: time.Date(2024, 1, 7, 23, 59, 59, 0, time.FixedZone("", 19800))},
+		{input: "2024-01-07 10:00 Europe/Paris", format: "yyyy-LL-dd HH:mm z", want: time.Date(2024, 1, 7, 10, 0, 0, 0, paris)},
+		{input: "2025-W01-1", format: "kkkk-'W'WW-c", want: time.Date(2024, 12, 30, 0, 0, 0, 0, time.Local)},
+		{input: "2009-W53-7", format: "kkkk-'W'WW-E", want: time.Date(2010, 1, 3, 0, 0, 0, 0, time.Local)},
+		{input: "day 060 of 2024", format: "'day' ooo 'of' yyyy", want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local)},
+		{input: "1746799240", format: "X", want: time.Unix(1746799240, 0)},
+		{input: "04/01/1997 15:09", format: "D T", want: time.Date(1997, 1, 4, 15, 9, 0, 0, time.Local)},
+		{input: "Saturday, 4 January 1997", format: "DDDD", want: time.Date(1997, 1, 4, 0, 0, 0, 0, time.Local)},
+		{input: "Q2 2024", format: "'Q'q yyyy", want: time.Date(2024, 4, 1, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := Luxon.Parse(testCase.input, testCase.format, en_GB.New())
+			if err != nil {
+				t.Errorf("Failed to parse: '%s' with format '%s'\n%s", testCase.input, testCase.format, err)
+				return
+			}
+			if got.Compare(testCase.want) != 0 {
+				t.Errorf("Fail\nGot:  %s\nwant: %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseLuxonLocale(t *testing.T) {
+	scenarios := []struct {
+		locale locales.Translator
+		input  string
+		format string
+	}{
+		{locale: fr_FR.New(), input: "samedi 4 janvier 1997 15:09", format: "cccc d LLLL yyyy T"},
+		{locale: fr_FR.New(), input: "4 janv. 1997, 15:09:00", format: "DD, tt"},
+		{locale: en_US.New(), input: "1/4/97 3:09 pm", format: "D t"},
+		{locale: es_ES.New(), input: "sábado, 4 de enero de 1997", format: "DDDD"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := Luxon.Parse(testCase.input, testCase.format, testCase.locale)
+			if err != nil {
+				t.Errorf("Failed to parse: '%s' with format '%s' in %s\n%s", testCase.input, testCase.format, testCase.locale.Locale(), err)
+				return
+			}
+			if got.Year() != 1997 || got.Month() != time.January || got.Day() != 4 {
+				t.Errorf("Fail\nGot:  %s\nwant: 1997-01-04", got)
+			}
+		})
+	}
+}
+
+func TestParseLuxonInvalid(t *testing.T) {
+	scenarios := []testCase{
+		{input: "2024-1-07", format: "yyyy-LL-dd"},
+		{input: "24-01-07", format: "yyyy-LL-dd"},
+		{input: "2024-01-07 Mars/Olympus", format: "yyyy-LL-dd z"},
+		{input: "S", format: "ccccc"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := Luxon.Parse(testCase.input, testCase.format, en_GB.New())
+			if err == nil {
+				t.Errorf("Expected '%s' to fail parsing with format '%s'\nGot: %s", testCase.input, testCase.format, got)
+			}
+		})
+	}
+}
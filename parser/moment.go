@@ -31,6 +31,7 @@ var tokenMapMoment = TokenMap{
 			}
 			return "pm"
 		},
+		parse: parseMeridiem,
 	},
 	"A": {
 		Desc: "Meridiem capitalised - 'AM'",
@@ -41,30 +42,36 @@ var tokenMapMoment = TokenMap{
 			}
 			return "PM"
 		},
+		parse: parseMeridiem,
 	},
 	"M": {
 		Desc:   "Month number (1-12)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.Month())) },
+		parse:  numberParser(1, 2, 1, 12, "month", setMonth),
 	},
 	"Mo": {
 		Desc:   "Month number suffixed - '1st', '13th', '22nd'",
 		expand: func(dt time.Time, locale locales.Translator) string { return numberSuffixed(int(dt.Month())) },
+		parse:  ordinalParser(2, 1, 12, "month", setMonth),
 	},
 	"MM": {
 		Desc:   "Month number zero padded to two digits - (01-12)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Month()) },
+		parse:  numberParser(1, 2, 1, 12, "month", setMonth),
 	},
 	"MMM": {
 		Desc: "Month name truncated to three characters - 'Jan', 'Feb'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.MonthWide(dt.Month())[:3]
 		},
+		parse: parseMonthName,
 	},
 	"MMMM": {
 		Desc: "Month name - 'January', 'February'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.MonthWide(dt.Month())
 		},
+		parse: parseMonthName,
 	},
 	"N": {
 		Desc: "Era name abbreviated - 'BC', 'AD'",
@@ -74,6 +81,7 @@ var tokenMapMoment = TokenMap{
 			}
 			return "AD"
 		},
+		parse:   parseEra,
 		aliases: []string{"NN", "NNN", "NNNNN"},
 	},
 	"NNNN": {
@@ -84,76 +92,92 @@ var tokenMapMoment = TokenMap{
 			}
 			return "Anno Domini"
 		},
+		parse: parseEra,
 	},
 	"Q": {
 		Desc:   "Quarter of year (1-4)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dateutils.YearQuarter(dt)) },
+		parse:  numberParser(1, 1, 1, 4, "quarter", setQuarter),
 	},
 	"Qo": {
 		Desc:   "Quarter of year suffixed - '1st', '2nd', '3rd', '4th'",
 		expand: func(dt time.Time, locale locales.Translator) string { return numberSuffixed(dateutils.YearQuarter(dt)) },
+		parse:  ordinalParser(1, 1, 4, "quarter", setQuarter),
 	},
 	"D": {
 		Desc:   "Day of month (1-31)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Day()) },
+		parse:  numberParser(1, 2, 1, 31, "day of month", setDay),
 	},
 	"Do": {
 		Desc:   "Day of month suffixed (1st-31st)",
 		expand: func(dt time.Time, locale locales.Translator) string { return numberSuffixed(dt.Day()) },
+		parse:  ordinalParser(2, 1, 31, "day of month", setDay),
 	},
 	"DD": {
 		Desc:   "Day of month zero padded to two digits (01-31)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Day()) },
+		parse:  numberParser(1, 2, 1, 31, "day of month", setDay),
 	},
 	"DDD": {
 		Desc:   "Day of year (1-366)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.YearDay()) },
+		parse:  numberParser(1, 3, 1, 366, "day of year", setYearDay),
 	},
 	"DDDo": {
 		Desc:   "Day of year suffixed (1st-366th)",
 		expand: func(dt time.Time, locale locales.Translator) string { return numberSuffixed(dt.YearDay()) },
+		parse:  ordinalParser(3, 1, 366, "day of year", setYearDay),
 	},
 	"DDDD": {
 		Desc:   "Day of year zero padded to three digits (001-366)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%03d", dt.YearDay()) },
+		parse:  numberParser(1, 3, 1, 366, "day of year", setYearDay),
 	},
 	"d": {
 		Desc:   "Day of week where Sunday = 0 and Saturday = 6 (0-6)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.Weekday())) },
+		parse:  numberParser(1, 1, 0, 6, "day of week", setWeekday),
 	},
 	"do": {
 		Desc:   "Day of week suffixed where Sunday = 0th and Saturday = 6th (0th-6th)",
 		expand: func(dt time.Time, locale locales.Translator) string { return numberSuffixed(int(dt.Weekday())) },
+		parse:  ordinalParser(1, 0, 6, "day of week", setWeekday),
 	},
 	"dd": {
 		Desc: "Day of week name truncated to two characters - 'Su', 'Mo'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.WeekdayShort(dt.Weekday())
 		},
+		parse: parseWeekdayName,
 	},
 	"ddd": {
 		Desc: "Day of week name truncated to three characters - 'Sun', 'Mon'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.WeekdayWide(dt.Weekday())[:3]
 		},
+		parse: parseWeekdayName,
 	},
 	"dddd": {
 		Desc: "Day of week name - 'Sunday', 'Monday'",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return locale.WeekdayWide(dt.Weekday())
 		},
+		parse: parseWeekdayName,
 	},
 	"e": {
 		Desc: "Day of week where Monday = 1 and Sunday = 0 - (0-6)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return strconv.Itoa(int(dt.Weekday()))
 		},
+		parse: numberParser(1, 1, 0, 6, "day of week", setWeekday),
 	},
 	"E": {
 		Desc: "Day of week where Monday = 1 and Sunday = 7 - (1-7)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return strconv.Itoa((int(dt.Weekday())+6)%7 + 1)
 		},
+		parse: numberParser(1, 1, 1, 7, "ISO day of week", setWeekday),
 	},
 	"gg": {
 		Desc: "Year of week where the last Sunday of the current week is used, truncated and zero padded to the last two digits - '97', '07'",
@@ -162,6 +186,9 @@ var tokenMapMoment = TokenMap{
 			return fmt.Sprintf("%02d", weekEnd.Year()%100)
 
 		},
+		parse: numberParser(2, 2, 0, 99, "week year", func(state *parseState, year int) {
+			setWeekYear(state, expandTwoDigitYear(year, 68))
+		}),
 	},
 	"gggg": {
 		Desc: "Year of week where the last Sunday of the current week is used - '1997', '2007'",
@@ -170,14 +197,17 @@ var tokenMapMoment = TokenMap{
 			return strconv.Itoa(weekEnd.Year())
 
 		},
+		parse: numberParser(1, 4, 0, 9999, "week year", setWeekYear),
 	},
 	"H": {
 		Desc:   "Hour in 24 hour format (0-23)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Hour()) },
+		parse:  numberParser(1, 2, 0, 23, "hour", setHour),
 	},
 	"HH": {
 		Desc:   "Hour in 24 hour format zero padded to two digits (00-23)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Hour()) },
+		parse:  numberParser(1, 2, 0, 23, "hour", setHour),
 	},
 	"h": {
 		Desc: "Hour in 12 hour format (1-12)",
@@ -188,6 +218,7 @@ var tokenMapMoment = TokenMap{
 			}
 			return strconv.Itoa(hour)
 		},
+		parse: numberParser(1, 2, 1, 12, "hour", setHour),
 	},
 	"hh": {
 		Desc: "Hour in 12 hour format zero padded to two digits (01-12)",
@@ -198,6 +229,7 @@ var tokenMapMoment = TokenMap{
 			}
 			return fmt.Sprintf("%02d", hour)
 		},
+		parse: numberParser(1, 2, 1, 12, "hour", setHour),
 	},
 	"k": {
 		Desc: "Hour in 24 hour format starting from 1 (1-24)",
@@ -208,6 +240,7 @@ var tokenMapMoment = TokenMap{
 			}
 			return strconv.Itoa(hour)
 		},
+		parse: numberParser(1, 2, 1, 24, "hour", setHour),
 	},
 	"kk": {
 		Desc: "Hour in 24 hour format starting from 1 zero padded to two digits (01-24)",
@@ -218,6 +251,7 @@ var tokenMapMoment = TokenMap{
 			}
 			return fmt.Sprintf("%02d", hour)
 		},
+		parse: numberParser(1, 2, 1, 24, "hour", setHour),
 	},
 	"w": {
 		Desc: "Week of year where the first Sunday before January 1st is considered week one (1-53)",
@@ -230,6 +264,7 @@ var tokenMapMoment = TokenMap{
 			weekDiff := midnight.Sub(firstSunday).Hours() / 24 / 7
 			return strconv.Itoa(int(weekDiff)%int(weeksInYear) + 1)
 		},
+		parse: numberParser(1, 2, 1, 53, "week", setWeek),
 	},
 	"wo": {
 		Desc: "Week of year suffixed where the first Sunday before January 1st is considered week one (1st-53rd)",
@@ -242,6 +277,7 @@ var tokenMapMoment = TokenMap{
 			weekDiff := midnight.Sub(firstSunday).Hours() / 24 / 7
 			return numberSuffixed(int(weekDiff)%int(weeksInYear) + 1)
 		},
+		parse: ordinalParser(2, 1, 53, "week", setWeek),
 	},
 	"ww": {
 		Desc: "Week of year where the first Sunday before January 1st is considered week one padded to two digits (01-53)",
@@ -254,6 +290,7 @@ var tokenMapMoment = TokenMap{
 			weekDiff := midnight.Sub(firstSunday).Hours() / 24 / 7
 			return fmt.Sprintf("%02d", int(weekDiff)%int(weeksInYear)+1)
 		},
+		parse: numberParser(1, 2, 1, 53, "week", setWeek),
 	},
 	"W": {
 		Desc: "ISO week of year (1-53)",
@@ -261,6 +298,7 @@ var tokenMapMoment = TokenMap{
 			_, week := dt.ISOWeek()
 			return strconv.Itoa(week)
 		},
+		parse: numberParser(1, 2, 1, 53, "ISO week", setISOWeek),
 	},
 	"Wo": {
 		Desc: "ISO week of year zero padded (01-53)",
@@ -268,6 +306,7 @@ var tokenMapMoment = TokenMap{
 			_, week := dt.ISOWeek()
 			return numberSuffixed(week)
 		},
+		parse: ordinalParser(2, 1, 53, "ISO week", setISOWeek),
 	},
 	"WW": {
 		Desc: "ISO week of year zero padded (01-53)",
@@ -275,11 +314,13 @@ var tokenMapMoment = TokenMap{
 			_, week := dt.ISOWeek()
 			return fmt.Sprintf("%02d", week)
 		},
+		parse: numberParser(1, 2, 1, 53, "ISO week", setISOWeek),
 	},
 
 	"Y": {
 		Desc:    "Year number - '1999', '2007'",
 		expand:  func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Year()) },
+		parse:   yearParser(1, 6),
 		aliases: []string{"y"},
 	},
 	"YY": {
@@ -287,94 +328,125 @@ var tokenMapMoment = TokenMap{
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d", dt.Year()%100)
 		},
+		parse: numberParser(2, 2, 0, 99, "year", func(state *parseState, year int) {
+			setYear(state, expandTwoDigitYear(year, 68))
+		}),
 		aliases: []string{"GG"},
 	},
 	"YYYY": {
 		Desc:    "Year number - '1999', '2007'",
 		expand:  func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Year()) },
+		parse:   yearParser(1, 4),
 		aliases: []string{"GGGG"},
 	},
+	"YYYYY": {
+		Desc: "Year number zero padded to 5 digits - '01999', '02007'",
+		expand: func(dt time.Time, locale locales.Translator) string {
+			year, sign := dt.Year(), ""
+			if year < 0 {
+				year, sign = -year, "-"
+			}
+			return fmt.Sprintf("%s%05d", sign, year)
+		},
+		parse: yearParser(4, 5),
+	},
 	"YYYYYY": {
 		Desc:   "Year number zeo padded to 6 digits - '001999', '002007'",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%+07d", dt.Year()) },
+		parse:  yearParser(4, 6),
 	},
 	"m": {
 		Desc:   "Minutes (0-59)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Minute()) },
+		parse:  numberParser(1, 2, 0, 59, "minute", setMinute),
 	},
 	"mm": {
 		Desc:   "Minutes zero padded to two digits (00-59)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Minute()) },
+		parse:  numberParser(1, 2, 0, 59, "minute", setMinute),
 	},
 	"s": {
 		Desc:   "Seconds (0-59)",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(dt.Second()) },
+		parse:  numberParser(1, 2, 0, 59, "second", setSecond),
 	},
 	"ss": {
 		Desc:   "Seconds zero padded to two digits (00-59)",
 		expand: func(dt time.Time, locale locales.Translator) string { return fmt.Sprintf("%02d", dt.Second()) },
+		parse:  numberParser(1, 2, 0, 59, "second", setSecond),
 	},
 	"S": {
 		Desc: "Fractional seconds to one digit (0-9)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return strconv.Itoa(dt.Nanosecond() / 100_000_000)
 		},
+		parse: fractionParser(1),
 	},
 	"SS": {
 		Desc: "Fractional seconds to two digits (00-99)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%02d", (dt.Nanosecond() / 10_000_000))
 		},
+		parse: fractionParser(2),
 	},
 	"SSS": {
 		Desc: "Fractional seconds to three digits (000-999)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%03d", (dt.Nanosecond() / 1_000_000))
 		},
+		parse: fractionParser(3),
 	},
 	"SSSS": {
 		Desc: "Fractional seconds to four digits (0000-9999)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%04d", (dt.Nanosecond() / 100_000))
 		},
+		parse: fractionParser(4),
 	},
 	"SSSSS": {
 		Desc: "Fractional seconds to five digits (00000-99999)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%05d", (dt.Nanosecond() / 10_000))
 		},
+		parse: fractionParser(5),
 	},
 	"SSSSSS": {
 		Desc: "Fractional seconds to six digits (000000-999999)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%06d", (dt.Nanosecond() / 1_000))
 		},
+		parse: fractionParser(6),
 	},
 	"SSSSSSS": {
 		Desc: "Fractional seconds to seven digits (0000000-9999999)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%07d", (dt.Nanosecond() / 100))
 		},
+		parse: fractionParser(7),
 	},
 	"SSSSSSSS": {
 		Desc: "Fractional seconds to eight digits (00000000-99999999)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%08d", dt.Nanosecond()/10)
 		},
+		parse: fractionParser(8),
 	},
 	"SSSSSSSSS": {
 		Desc: "Fractional seconds to eight digits (00000000-99999999)",
 		expand: func(dt time.Time, locale locales.Translator) string {
 			return fmt.Sprintf("%09d", dt.Nanosecond())
 		},
+		parse: fractionParser(9),
 	},
 	"X": {
 		Desc:   "Unix timestamp in seconds",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.Unix())) },
+		parse:  unixParser(time.Second),
 	},
 	"x": {
 		Desc:   "Unix timestamp in milliseconds",
 		expand: func(dt time.Time, locale locales.Translator) string { return strconv.Itoa(int(dt.UnixMilli())) },
+		parse:  unixParser(time.Millisecond),
 	},
 	"z": {
 		Desc: "Abbreviated time zone offset - 'GMT', 'CEST', '+0530'",
@@ -382,6 +454,7 @@ var tokenMapMoment = TokenMap{
 			offsetName, _ := dt.Zone()
 			return offsetName
 		},
+		parse:   parseZoneAbbreviation,
 		aliases: []string{"zz"},
 	},
 	"Z": {
@@ -392,6 +465,7 @@ var tokenMapMoment = TokenMap{
 			offsetHours := offsetMinutes / 60
 			return fmt.Sprintf("%+03d:%02d", offsetHours, offsetMinutes%60)
 		},
+		parse: parseOffset,
 	},
 	"ZZ": {
 		Desc: "Time zone offset formatted without the dividing ':' - '+0530', '-0300'",
@@ -401,5 +475,6 @@ var tokenMapMoment = TokenMap{
 			offsetHours := offsetMinutes / 60
 			return fmt.Sprintf("%+03d%02d", offsetHours, offsetMinutes%60)
 		},
+		parse: parseOffset,
 	},
 }
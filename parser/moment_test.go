@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/go-playground/locales/en_GB"
+	"github.com/go-playground/locales/fr_FR"
 )
 
 func TestTokensMoment(t *testing.T) {
@@ -34,3 +35,108 @@ func TestTokensMoment(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatMoment(t *testing.T) {
+	dt := time.Date(1997, 1, 4, 15, 9, 2, 0, time.UTC)
+	scenarios := []struct {
+		format string
+		want   string
+	}{
+		{format: "YYY", want: "YYY"},
+		{format: "YYYYY", want: "01997"},
+		{format: "YYYYYY", want: "+001997"},
+		{format: "YYYY-MM-DD", want: "1997-01-04"},
+		{format: "YYYMM", want: "YYY01"},
+		{format: "[YYY] YY", want: "YYY 97"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if got := MomentJs.Format(dt, en_GB.New(), &testCase.format); got != testCase.want {
+				t.Errorf("Fail formatting %q\nGot:  %s\nwant: %s", testCase.format, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseMoment(t *testing.T) {
+	scenarios := []testCase{
+		{input: "4th January 1997, 3:09 pm", format: "Do MMMM YYYY, h:mm a", want: time.Date(1997, 1, 4, 15, 9, 0, 0, time.Local)},
+		{input: "Sat 04 Jan 97 12:00 AM", format: "ddd DD MMM YY hh:mm A", want: time.Date(1997, 1, 4, 0, 0, 0, 0, time.Local)},
+		{input: "2024-01-07T23:59:59.123+05:30", format: "YYYY-MM-DDTHH:mm:ss.SSSZ", want: time.Date(2024, 1, 7, 23, 59, 59, 123_000_000, time.FixedZone("", 19800))},
+		{input: "20240107 -0800", format: "YYYYMMDD ZZ", want: time.Date(2024, 1, 7, 0, 0, 0, 0, time.FixedZone("", -28800))},
+		{input: "day 366 of 2024", format: "[day] DDDD [of] YYYY", want: time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local)},
+		{input: "1746799240", format: "X", want: time.Unix(1746799240, 0)},
+		{input: "1746799240123", format: "x", want: time.UnixMilli(1746799240123)},
+		{input: "2024 W1 E1", format: "GGGG [W]W [E]E", want: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)},
+		{input: "Q3 1989", format: "[Q]Q YYYY", want: time.Date(1989, 7, 1, 0, 0, 0, 0, time.Local)},
+		{input: "01997-01-04", format: "YYYYY-MM-DD", want: time.Date(1997, 1, 4, 0, 0, 0, 0, time.Local)},
+		{input: "24:00 31/12/1989", format: "kk:mm DD/MM/YYYY", want: time.Date(1990, 1, 1, 0, 0, 0, 0, time.Local)},
+		{input: "2025-05-09 15:00 UTC", format: "YYYY-MM-DD HH:mm z", want: time.Date(2025, 5, 9, 15, 0, 0, 0, time.UTC)},
+		{input: "2025-05-09 15:00 +02:00 CEST", format: "YYYY-MM-DD HH:mm Z z", want: time.Date(2025, 5, 9, 15, 0, 0, 0, time.FixedZone("", 7200))},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := MomentJs.Parse(testCase.input, testCase.format, en_GB.New())
+			if err != nil {
+				t.Errorf("Failed to parse: '%s' with format '%s'\n%s", testCase.input, testCase.format, err)
+				return
+			}
+			if got.Compare(testCase.want) != 0 {
+				t.Errorf("Fail\nGot:  %s\nwant: %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseMomentLocale(t *testing.T) {
+	got, err := MomentJs.Parse("1er janvier 2007, lun.", "D[er] MMMM YYYY, ddd", fr_FR.New())
+	if err != nil {
+		t.Fatalf("Failed to parse with the fr_FR locale\n%s", err)
+	}
+	if want := time.Date(2007, 1, 1, 0, 0, 0, 0, time.Local); got.Compare(want) != 0 {
+		t.Errorf("Fail\nGot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestParseMomentPrefix(t *testing.T) {
+	got, consumed, err := MomentJs.ParsePrefix("2024-01-07 23:59 request started", "YYYY-MM-DD HH:mm", en_GB.New())
+	if err != nil {
+		t.Fatalf("Failed to parse the start of the input\n%s", err)
+	}
+	if consumed != len("2024-01-07 23:59") {
+		t.Errorf("Expected 16 bytes to be consumed\nGot: %d", consumed)
+	}
+	if want := time.Date(2024, 1, 7, 23, 59, 0, 0, time.Local); got.Compare(want) != 0 {
+		t.Errorf("Fail\nGot:  %s\nwant: %s", got, want)
+	}
+
+	if _, _, err := MomentJs.ParsePrefix("request started 2024-01-07", "YYYY-MM-DD", en_GB.New()); err == nil {
+		t.Errorf("Expected an input not starting with the layout to fail parsing")
+	}
+}
+
+func TestParseMomentInvalid(t *testing.T) {
+	scenarios := []testCase{
+		{input: "2024-02-30", format: "YYYY-MM-DD"},
+		{input: "2024-13-01", format: "YYYY-MM-DD"},
+		{input: "Fri 2024-01-07", format: "ddd YYYY-MM-DD"},
+		{input: "2024-01-07 trailing", format: "YYYY-MM-DD"},
+		{input: "2024/01/07", format: "YYYY-MM-DD"},
+		{input: "13:00 pm", format: "h:mm a"},
+		{input: "2025-05-09 15:00 XYZT", format: "YYYY-MM-DD HH:mm z"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			got, err := MomentJs.Parse(testCase.input, testCase.format, en_GB.New())
+			if err == nil {
+				t.Errorf("Expected '%s' to fail parsing with format '%s'\nGot: %s", testCase.input, testCase.format, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,396 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/monokuro/era/dateutils"
+)
+
+// Components of a natural language expression collected before being combined into a
+// date time relative to a reference
+type naturalState struct {
+	location *time.Location
+
+	// Absolute date e.g. '2025-05-09'
+	date    time.Time
+	hasDate bool
+
+	// Weekday relative to the reference where `weekdayShift` selects the previous (-1),
+	// coming (0) or following (1) occurrence
+	weekday      time.Weekday
+	weekdayShift int
+	hasWeekday   bool
+
+	// First or last day of a week, month or year offset by `periodShift` periods
+	dayOf       string
+	period      string
+	periodShift int
+
+	// Calendar units are applied before the time of day and exact durations after
+	offset dateutils.CalendarDuration
+
+	hour, minute, second, nano int
+	hasClock                   bool
+}
+
+var (
+	isoDatePattern    = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	clockPattern      = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2})(?:\.(\d{1,9}))?)?(am|pm|a\.m\.|p\.m\.)?$`)
+	amountPattern     = regexp.MustCompile(`^(?:\d+(?:\.\d+)?|an?)$`)
+	durationPattern   = regexp.MustCompile(`^(?:\d+(?:\.\d+)?[a-zµ]+)+$`)
+	componentsPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)([a-zµ]+)`)
+)
+
+// Parses a natural language date time expression relative to `now` in the style of
+// GNU `date -d` e.g. 'tomorrow 9am', 'next friday', '3 days ago', 'in 2h30m',
+// 'last day of next month' or 'monday 14:00 Europe/Paris'
+//
+// A trailing IANA time zone interprets the expression in that zone. Relative days
+// keep the time of day whereas weekdays and dates start at midnight unless a time
+// is given
+func ParseNatural(input string, now time.Time) (time.Time, error) {
+	state := naturalState{location: now.Location()}
+
+	fields := strings.FieldsFunc(input, func(char rune) bool {
+		return char == ' ' || char == '\t' || char == '\n' || char == ','
+	})
+	words := make([]string, len(fields))
+	for idx, field := range fields {
+		words[idx] = strings.ToLower(field)
+	}
+	if len(words) == 0 {
+		return time.Time{}, fmt.Errorf("Empty date expression")
+	}
+
+	for idx := 0; idx < len(words); {
+		consumed, err := state.parseWord(words, fields, idx)
+		if err != nil {
+			return time.Time{}, err
+		}
+		idx += consumed
+	}
+
+	return state.resolve(now), nil
+}
+
+// Reads the expression starting at `words[idx]` returning the number of words consumed
+func (state *naturalState) parseWord(words, fields []string, idx int) (int, error) {
+	word := words[idx]
+	next := func(offset int) string {
+		if idx+offset < len(words) {
+			return words[idx+offset]
+		}
+		return ""
+	}
+
+	switch word {
+	case "now", "today", "at", "on", "and", "the":
+		return 1, nil
+	case "tomorrow":
+		state.offset.Days += 1
+		return 1, nil
+	case "yesterday":
+		state.offset.Days -= 1
+		return 1, nil
+	case "noon", "midday":
+		state.setClock(12, 0, 0, 0)
+		return 1, nil
+	case "midnight":
+		state.setClock(0, 0, 0, 0)
+		return 1, nil
+	case "in":
+		consumed, err := state.parseAmounts(words, idx+1, 1)
+		if err != nil {
+			return 0, err
+		}
+		if consumed == 0 {
+			return 0, fmt.Errorf("Expected an amount of time after %q", fields[idx])
+		}
+		return consumed + 1, nil
+	case "first", "last":
+		if next(1) == "day" && next(2) == "of" {
+			consumed, err := state.parseDayOf(word, words, idx+3)
+			if err != nil {
+				return 0, err
+			}
+			return consumed + 3, nil
+		}
+	}
+
+	if shift, ok := naturalShifts[word]; ok {
+		if weekday, ok := parseNaturalWeekday(next(1)); ok {
+			state.setWeekday(weekday, shift)
+			return 2, nil
+		}
+		if unit := next(1); unit != "" {
+			if err := state.offset.Add(float64(shift), unit); err == nil {
+				return 2, nil
+			}
+		}
+		return 0, fmt.Errorf("Expected a weekday or unit after %q", fields[idx])
+	}
+
+	if weekday, ok := parseNaturalWeekday(word); ok {
+		state.setWeekday(weekday, 0)
+		return 1, nil
+	}
+
+	if match := isoDatePattern.FindStringSubmatch(word); match != nil {
+		dt, err := time.ParseInLocation(time.DateOnly, word, time.UTC)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid date %q", fields[idx])
+		}
+		state.date, state.hasDate = dt, true
+		return 1, nil
+	}
+
+	clock := word
+	consumed := 1
+	if meridiem := next(1); meridiem == "am" || meridiem == "pm" || meridiem == "a.m." || meridiem == "p.m." {
+		clock += meridiem
+		consumed = 2
+	}
+	if match := clockPattern.FindStringSubmatch(clock); match != nil && (match[2] != "" || match[5] != "") {
+		if err := state.parseClock(match); err != nil {
+			return 0, err
+		}
+		return consumed, nil
+	}
+
+	if consumed, err := state.parseAmounts(words, idx, 0); err != nil {
+		return 0, err
+	} else if consumed > 0 {
+		return consumed, nil
+	}
+
+	if strings.Contains(fields[idx], "/") || word == "utc" || word == "gmt" {
+		name := fields[idx]
+		if word == "utc" || word == "gmt" {
+			name = "UTC"
+		}
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			return 0, fmt.Errorf("Unknown time zone %q", fields[idx])
+		}
+		state.location = location
+		return 1, nil
+	}
+
+	return 0, fmt.Errorf("Unrecognised word %q", fields[idx])
+}
+
+var naturalShifts = map[string]int{
+	"next":     1,
+	"last":     -1,
+	"previous": -1,
+	"this":     0,
+}
+
+func parseNaturalWeekday(word string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if word == name || word == name[:3] || word == name+"s" {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+func (state *naturalState) setWeekday(weekday time.Weekday, shift int) {
+	state.weekday, state.weekdayShift, state.hasWeekday = weekday, shift, true
+}
+
+func (state *naturalState) setClock(hour, minute, second, nano int) {
+	state.hour, state.minute, state.second, state.nano = hour, minute, second, nano
+	state.hasClock = true
+}
+
+// Reads a time of day from the submatches of `clockPattern`
+func (state *naturalState) parseClock(match []string) error {
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	second, _ := strconv.Atoi(match[3])
+	nano := 0
+	if match[4] != "" {
+		nano, _ = strconv.Atoi(match[4] + strings.Repeat("0", 9-len(match[4])))
+	}
+
+	if match[5] != "" {
+		if hour < 1 || hour > 12 {
+			return fmt.Errorf("Hour %d is out of range for a 12 hour time", hour)
+		}
+		hour %= 12
+		if match[5][0] == 'p' {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 || second > 59 {
+		return fmt.Errorf("Invalid time %q", match[0])
+	}
+
+	state.setClock(hour, minute, second, nano)
+	return nil
+}
+
+// Reads the period after 'first day of' or 'last day of' e.g. 'next month'
+func (state *naturalState) parseDayOf(dayOf string, words []string, idx int) (int, error) {
+	consumed := 0
+	shift := 0
+	if idx < len(words) {
+		if wordShift, ok := naturalShifts[words[idx]]; ok {
+			shift = wordShift
+			consumed++
+		}
+	}
+	if idx+consumed >= len(words) {
+		return 0, fmt.Errorf("Expected a week, month or year after %q", dayOf+" day of")
+	}
+
+	switch period := strings.TrimSuffix(words[idx+consumed], "s"); period {
+	case "week", "month", "year":
+		state.dayOf, state.period, state.periodShift = dayOf, period, shift
+	default:
+		return 0, fmt.Errorf("Expected a week, month or year after %q", dayOf+" day of")
+	}
+	return consumed + 1, nil
+}
+
+// Reads consecutive amounts of time such as '3 days', 'an hour' or '2h30m' followed
+// by an optional 'ago' or 'from now' returning the number of words consumed
+//
+// Amounts are added when `sign` is positive and subtracted when followed by 'ago',
+// a `sign` of zero allows either
+func (state *naturalState) parseAmounts(words []string, idx int, sign int) (int, error) {
+	type component struct {
+		value float64
+		unit  string
+	}
+	var components []component
+
+	start := idx
+	for idx < len(words) {
+		word := words[idx]
+		switch {
+		case amountPattern.MatchString(word) && idx+1 < len(words) && isNaturalUnit(words[idx+1]):
+			value := 1.
+			if word != "a" && word != "an" {
+				value, _ = strconv.ParseFloat(word, 64)
+			}
+			components = append(components, component{value, words[idx+1]})
+			idx += 2
+			continue
+		case durationPattern.MatchString(word):
+			for _, match := range componentsPattern.FindAllStringSubmatch(word, -1) {
+				value, _ := strconv.ParseFloat(match[1], 64)
+				components = append(components, component{value, match[2]})
+			}
+			idx += 1
+			continue
+		case word == "and" && len(components) > 0:
+			idx += 1
+			continue
+		}
+		break
+	}
+	if len(components) == 0 {
+		return 0, nil
+	}
+
+	direction := 1
+	switch {
+	case idx < len(words) && words[idx] == "ago":
+		if sign > 0 {
+			return 0, fmt.Errorf("Unexpected %q after 'in'", "ago")
+		}
+		direction = -1
+		idx++
+	case idx+1 < len(words) && words[idx] == "from" && words[idx+1] == "now":
+		idx += 2
+	case idx < len(words) && (words[idx] == "later" || words[idx] == "hence"):
+		idx++
+	}
+
+	for _, component := range components {
+		if err := state.offset.Add(float64(direction)*component.value, component.unit); err != nil {
+			return 0, err
+		}
+	}
+	return idx - start, nil
+}
+
+func isNaturalUnit(unit string) bool {
+	if _, ok := dateutils.CalendarUnit(unit); ok {
+		return true
+	}
+	_, err := dateutils.DurationUnit(unit)
+	return err == nil
+}
+
+// Combines the collected components relative to `now`
+//
+// Dates and weekdays are resolved first followed by the first or last day of a period,
+// calendar offsets, the time of day and finally exact durations
+func (state *naturalState) resolve(now time.Time) time.Time {
+	dt := now.In(state.location)
+	startOfDay := false
+
+	if state.hasDate {
+		year, month, day := state.date.Date()
+		dt = time.Date(year, month, day, dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), state.location)
+		startOfDay = true
+	}
+
+	if state.hasWeekday {
+		from := dateutils.DayStart(dt)
+		switch state.weekdayShift {
+		case 1:
+			from = from.AddDate(0, 0, 1)
+		case -1:
+			from = from.AddDate(0, 0, -7)
+		}
+		dt = dateutils.NextWeekday(state.weekday, from)
+		startOfDay = true
+	}
+
+	if state.dayOf != "" {
+		hour, minute, second := dt.Clock()
+		year, month, day := dt.Date()
+		switch state.period {
+		case "week":
+			start := dateutils.WeekStart(time.Monday, dt).AddDate(0, 0, 7*state.periodShift)
+			year, month, day = start.Date()
+			if state.dayOf == "last" {
+				day += 6
+			}
+		case "month":
+			month += time.Month(state.periodShift)
+			day = 1
+			if state.dayOf == "last" {
+				month, day = month+1, 0
+			}
+		case "year":
+			year += state.periodShift
+			month, day = time.January, 1
+			if state.dayOf == "last" {
+				month, day = time.December, 31
+			}
+		}
+		dt = time.Date(year, month, day, hour, minute, second, dt.Nanosecond(), state.location)
+	}
+
+	dt = dateutils.AddDate(dt, state.offset.Years, state.offset.Months, state.offset.Days)
+
+	switch {
+	case state.hasClock:
+		year, month, day := dt.Date()
+		dt = time.Date(year, month, day, state.hour, state.minute, state.second, state.nano, state.location)
+	case startOfDay:
+		dt = dateutils.DayStart(dt)
+	}
+
+	return dt.Add(state.offset.Duration)
+}
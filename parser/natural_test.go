@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNatural(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	paris, _ := time.LoadLocation("Europe/Paris")
+	// Wednesday
+	now := time.Date(2025, 1, 15, 10, 30, 0, 0, london)
+
+	scenarios := []struct {
+		input string
+		want  time.Time
+	}{
+		{input: "now", want: now},
+		{input: "tomorrow", want: time.Date(2025, 1, 16, 10, 30, 0, 0, london)},
+		{input: "tomorrow 9am", want: time.Date(2025, 1, 16, 9, 0, 0, 0, london)},
+		{input: "yesterday at 5:30 p.m.", want: time.Date(2025, 1, 14, 17, 30, 0, 0, london)},
+		{input: "today noon", want: time.Date(2025, 1, 15, 12, 0, 0, 0, london)},
+		{input: "wednesday", want: time.Date(2025, 1, 15, 0, 0, 0, 0, london)},
+		{input: "friday", want: time.Date(2025, 1, 17, 0, 0, 0, 0, london)},
+		{input: "next friday", want: time.Date(2025, 1, 17, 0, 0, 0, 0, london)},
+		{input: "next wednesday", want: time.Date(2025, 1, 22, 0, 0, 0, 0, london)},
+		{input: "last wednesday", want: time.Date(2025, 1, 8, 0, 0, 0, 0, london)},
+		{input: "last fri 18:00", want: time.Date(2025, 1, 10, 18, 0, 0, 0, london)},
+		{input: "3 days ago", want: time.Date(2025, 1, 12, 10, 30, 0, 0, london)},
+		{input: "2 weeks from now", want: time.Date(2025, 1, 29, 10, 30, 0, 0, london)},
+		{input: "an hour ago", want: time.Date(2025, 1, 15, 9, 30, 0, 0, london)},
+		{input: "in 2h30m", want: time.Date(2025, 1, 15, 13, 0, 0, 0, london)},
+		{input: "in 1 hour and 15 minutes", want: time.Date(2025, 1, 15, 11, 45, 0, 0, london)},
+		{input: "in 1 month", want: time.Date(2025, 2, 15, 10, 30, 0, 0, london)},
+		{input: "next year", want: time.Date(2026, 1, 15, 10, 30, 0, 0, london)},
+		{input: "last week", want: time.Date(2025, 1, 8, 10, 30, 0, 0, london)},
+		{input: "last day of next month", want: time.Date(2025, 2, 28, 10, 30, 0, 0, london)},
+		{input: "first day of this month midnight", want: time.Date(2025, 1, 1, 0, 0, 0, 0, london)},
+		{input: "last day of last year", want: time.Date(2024, 12, 31, 10, 30, 0, 0, london)},
+		{input: "first day of next week 9am", want: time.Date(2025, 1, 20, 9, 0, 0, 0, london)},
+		{input: "2025-03-01 14:00", want: time.Date(2025, 3, 1, 14, 0, 0, 0, london)},
+		{input: "monday 14:00 Europe/Paris", want: time.Date(2025, 1, 20, 14, 0, 0, 0, paris)},
+		{input: "tomorrow 9am UTC", want: time.Date(2025, 1, 16, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.input, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseNatural(testCase.input, now)
+			if err != nil {
+				t.Fatalf("Failed to parse '%s': %s", testCase.input, err)
+			}
+			if !got.Equal(testCase.want) {
+				t.Errorf("Expected '%s' to parse as %s\nGot: %s", testCase.input, testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestParseNaturalDaylightSaving(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	// The day before the clocks go forward
+	now := time.Date(2025, 3, 29, 12, 0, 0, 0, london)
+
+	got, err := ParseNatural("tomorrow", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 3, 30, 12, 0, 0, 0, london); !got.Equal(want) {
+		t.Errorf("Expected calendar days to keep the time of day %s\nGot: %s", want, got)
+	}
+
+	got, err = ParseNatural("in 24h", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 3, 30, 13, 0, 0, 0, london); !got.Equal(want) {
+		t.Errorf("Expected exact durations to ignore the time of day %s\nGot: %s", want, got)
+	}
+
+	// 01:30 is skipped when the clocks go forward and repeated when they go back
+	got, err = ParseNatural("tomorrow", time.Date(2025, 3, 29, 1, 30, 0, 0, london))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 3, 30, 2, 30, 0, 0, london); !got.Equal(want) {
+		t.Errorf("Expected a skipped time to move forward %s\nGot: %s", want, got)
+	}
+
+	got, err = ParseNatural("tomorrow", time.Date(2025, 10, 25, 1, 30, 0, 0, london))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Expected a repeated time to use the earlier instant %s\nGot: %s", want, got)
+	}
+}
+
+func TestParseNaturalMonthEnd(t *testing.T) {
+	now := time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC)
+
+	for input, want := range map[string]time.Time{
+		"in 1 month":     time.Date(2024, 2, 29, 10, 30, 0, 0, time.UTC),
+		"in 2 months":    time.Date(2024, 3, 31, 10, 30, 0, 0, time.UTC),
+		"1 month ago":    time.Date(2023, 12, 31, 10, 30, 0, 0, time.UTC),
+		"in 1mo 1d":      time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC),
+		"in 13 months":   time.Date(2025, 2, 28, 10, 30, 0, 0, time.UTC),
+		"next year":      time.Date(2025, 1, 31, 10, 30, 0, 0, time.UTC),
+		"in 1 fortnight": time.Date(2024, 2, 14, 10, 30, 0, 0, time.UTC),
+	} {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseNatural(input, now)
+			if err != nil {
+				t.Fatalf("Failed to parse '%s': %s", input, err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("Expected '%s' to clamp to the end of the month %s\nGot: %s", input, want, got)
+			}
+		})
+	}
+}
+
+func TestParseNaturalInvalid(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	for _, input := range []string{"", "blah", "in", "in 3 days ago", "next", "last day of", "first day of the decade", "1.5 months ago", "13pm", "25:00", "tomorrow Mars/Olympus"} {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseNatural(input, now)
+			if err == nil {
+				t.Errorf("Expected '%s' to fail parsing\nGot: %s", input, got)
+			}
+		})
+	}
+}
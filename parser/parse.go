@@ -0,0 +1,847 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gitlab.com/monokuro/era/dateutils"
+
+	"github.com/go-playground/locales"
+)
+
+// Error returned when an input could not be matched against a layout
+type ParseError struct {
+	Input  string
+	Layout string
+	// Byte offset into the input at which parsing failed or -1 when unknown
+	Offset int
+	Err    error
+}
+
+func (err *ParseError) Error() string {
+	if err.Offset < 0 {
+		return fmt.Sprintf("Unable to parse %q with layout %q: %s", err.Input, err.Layout, err.Err)
+	}
+	return fmt.Sprintf("Unable to parse %q with layout %q at position %d: %s", err.Input, err.Layout, err.Offset, err.Err)
+}
+
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
+
+// Parses the input against the tokenised layout resolving the result relative to `base`
+func parseLayout(input, layout string, tokens []layoutToken, locale locales.Translator, base time.Time) (time.Time, error) {
+	state := parseState{locale: locale}
+	offset, err := parseTokens(&state, input, tokens)
+	if err != nil {
+		return time.Time{}, &ParseError{Input: input, Layout: layout, Offset: offset, Err: err}
+	}
+
+	dt, err := state.resolve(base)
+	if err != nil {
+		return time.Time{}, &ParseError{Input: input, Layout: layout, Offset: offset, Err: err}
+	}
+	return dt, nil
+}
+
+// Parses the longest prefix of the input matching the tokenised layout returning the
+// result relative to `base` and the number of bytes consumed
+func parseLayoutPrefix(input, layout string, tokens []layoutToken, locale locales.Translator, base time.Time) (time.Time, int, error) {
+	state := parseState{locale: locale}
+	offset, err := matchTokens(&state, input, tokens)
+	if err != nil {
+		return time.Time{}, 0, &ParseError{Input: input, Layout: layout, Offset: offset, Err: err}
+	}
+
+	dt, err := state.resolve(base)
+	if err != nil {
+		return time.Time{}, 0, &ParseError{Input: input, Layout: layout, Offset: offset, Err: err}
+	}
+	return dt, offset, nil
+}
+
+// Reads each token in turn from the input returning the offset reached
+//
+// Literal sections of the layout must match the input exactly and the whole input
+// must be consumed
+func parseTokens(state *parseState, input string, tokens []layoutToken) (int, error) {
+	return matchFrom(state, input, 0, tokens, func(offset int) (int, error) {
+		if offset < len(input) {
+			return offset, fmt.Errorf("Unexpected trailing input %q", input[offset:])
+		}
+		return offset, nil
+	})
+}
+
+// Reads each token in turn from the start of the input returning the offset reached
+// without requiring the whole input to be consumed
+func matchTokens(state *parseState, input string, tokens []layoutToken) (int, error) {
+	return matchFrom(state, input, 0, tokens, func(offset int) (int, error) { return offset, nil })
+}
+
+// Reads the tokens from the input starting at `offset` and then hands the offset
+// reached on to `next` which reads whatever follows them
+//
+// Optional sections are read with their contents first and left out when either they
+// or anything after them fails to match, restoring the state to before the section
+func matchFrom(state *parseState, input string, offset int, tokens []layoutToken, next func(offset int) (int, error)) (int, error) {
+	for idx, token := range tokens {
+		if token.optionalStart {
+			// Unclosed optional sections run until the end of the layout
+			end, depth := len(tokens), 0
+			for closeIdx := idx; closeIdx < len(tokens); closeIdx++ {
+				if tokens[closeIdx].optionalStart {
+					depth++
+				} else if tokens[closeIdx].optionalEnd {
+					depth--
+				}
+				if depth == 0 {
+					end = closeIdx
+					break
+				}
+			}
+			rest := tokens[min(end+1, len(tokens)):]
+			after := func(offset int) (int, error) { return matchFrom(state, input, offset, rest, next) }
+
+			saved := *state
+			reached, err := matchFrom(state, input, offset, tokens[idx+1:end], after)
+			if err == nil {
+				return reached, nil
+			}
+			*state = saved
+			if skipped, skipErr := after(offset); skipErr == nil {
+				return skipped, nil
+			}
+			return reached, err
+		}
+		if token.unknown {
+			return offset, fmt.Errorf("Unknown token %q", token.raw)
+		}
+		if token.def == nil {
+			if !strings.HasPrefix(input[offset:], token.literal) {
+				return offset, fmt.Errorf("Expected %q", token.literal)
+			}
+			offset += len(token.literal)
+			continue
+		}
+
+		if token.def.parse == nil {
+			return offset, fmt.Errorf("Token %q does not support parsing", token.raw)
+		}
+		consumed, err := token.def.parse(state, input[offset:])
+		if err != nil {
+			return offset, err
+		}
+		offset += consumed
+	}
+	return next(offset)
+}
+
+// Bit flags recording which date time components have been read while parsing
+type parseField uint
+
+const (
+	fieldYear parseField = 1 << iota
+	fieldMonth
+	fieldDay
+	fieldYearDay
+	fieldWeekday
+	fieldQuarter
+	fieldISOYear
+	fieldISOWeek
+	fieldWeekYear
+	fieldWeek
+	fieldYearWeek
+	fieldCentury
+	fieldUnix
+)
+
+// Any field which determines the calendar date rather than the time of day
+const dateFields = fieldYear | fieldMonth | fieldDay | fieldYearDay | fieldQuarter | fieldISOYear | fieldISOWeek | fieldWeekYear | fieldWeek | fieldYearWeek | fieldCentury
+
+type meridiem int
+
+const (
+	meridiemNone meridiem = iota
+	meridiemAM
+	meridiemPM
+)
+
+// Date time components collected from an input by token parse functions
+//
+// Components are only combined into a `time.Time` once every token has been read so
+// tokens may appear in any order within a layout
+type parseState struct {
+	locale   locales.Translator
+	fields   parseField
+	year     int
+	month    time.Month
+	day      int
+	yearDay  int
+	weekday  time.Weekday
+	quarter  int
+	isoYear  int
+	isoWeek  int
+	weekYear int
+	week     int
+	// Week of the year where week 1 starts on the first `yearWeekStart` of January
+	yearWeek      int
+	yearWeekStart time.Weekday
+	century       int
+	hour          int
+	minute        int
+	second        int
+	nano          int
+	meridiem      meridiem
+	bc            bool
+	location      *time.Location
+	// Time zone abbreviation other than UTC which must match the resolved location
+	abbreviation string
+	unix         time.Time
+}
+
+// Combines the parsed components into a single date time
+//
+// Any date components that were not parsed are taken from `base` when no date
+// components were parsed at all, otherwise they default to the start of the period
+// that was parsed e.g. a year on its own resolves to January 1st
+func (state *parseState) resolve(base time.Time) (time.Time, error) {
+	location := base.Location()
+	if state.location != nil {
+		location = state.location
+	}
+
+	if state.fields&fieldUnix != 0 {
+		return state.unix.In(location), nil
+	}
+
+	year, month, day := base.Date()
+	if state.fields&dateFields != 0 {
+		month, day = time.January, 1
+	}
+	if state.fields&fieldYear != 0 {
+		year = state.year
+		if state.bc && year > 0 {
+			year = -year
+		}
+	}
+	if state.fields&fieldCentury != 0 {
+		year = state.century*100 + year%100
+		if state.fields&fieldYear == 0 {
+			year = state.century * 100
+		}
+	}
+
+	switch {
+	case state.fields&(fieldMonth|fieldDay) != 0:
+		if state.fields&fieldMonth != 0 {
+			month = state.month
+		} else if state.fields&fieldQuarter != 0 {
+			month = time.Month((state.quarter-1)*3 + 1)
+		}
+		if state.fields&fieldDay != 0 {
+			day = state.day
+		}
+		if daysIn := daysInMonth(year, month); day > daysIn {
+			return time.Time{}, fmt.Errorf("Day %d is out of range for %s %d", day, month, year)
+		}
+	case state.fields&fieldYearDay != 0:
+		if daysIn := daysInYear(year); state.yearDay > daysIn {
+			return time.Time{}, fmt.Errorf("Day of year %d is out of range for %d", state.yearDay, year)
+		}
+		month, day = time.January, state.yearDay
+	case state.fields&(fieldISOWeek|fieldISOYear) != 0:
+		isoYear, isoWeek := year, 1
+		if state.fields&fieldISOYear != 0 {
+			isoYear = state.isoYear
+		}
+		if state.fields&fieldISOWeek != 0 {
+			isoWeek = state.isoWeek
+		}
+		weekday := time.Monday
+		if state.fields&fieldWeekday != 0 {
+			weekday = state.weekday
+		}
+		year, month, day = isoWeekDate(isoYear, isoWeek, weekday).Date()
+	case state.fields&(fieldWeek|fieldWeekYear) != 0:
+		weekYear, week := year, 1
+		if state.fields&fieldWeekYear != 0 {
+			weekYear = state.weekYear
+		}
+		if state.fields&fieldWeek != 0 {
+			week = state.week
+		}
+		weekday := time.Sunday
+		if state.fields&fieldWeekday != 0 {
+			weekday = state.weekday
+		}
+		year, month, day = localeWeekDate(weekYear, week, weekday).Date()
+	case state.fields&fieldYearWeek != 0:
+		weekday := state.yearWeekStart
+		if state.fields&fieldWeekday != 0 {
+			weekday = state.weekday
+		}
+		year, month, day = yearWeekDate(year, state.yearWeek, state.yearWeekStart, weekday).Date()
+	case state.fields&fieldQuarter != 0:
+		month = time.Month((state.quarter-1)*3 + 1)
+	}
+
+	hour := state.hour
+	switch state.meridiem {
+	case meridiemAM:
+		if hour == 12 {
+			hour = 0
+		}
+	case meridiemPM:
+		if hour < 12 {
+			hour += 12
+		}
+	}
+
+	dt := time.Date(year, month, day, hour, state.minute, state.second, state.nano, location)
+	// An offset alongside the abbreviation already gives the time zone
+	if name, _ := dt.Zone(); state.abbreviation != "" && state.location == nil && state.abbreviation != strings.ToUpper(name) {
+		return dt, fmt.Errorf("Ambiguous time zone abbreviation %q, use a numeric offset or a time zone name instead", state.abbreviation)
+	}
+	if state.fields&fieldWeekday != 0 && state.fields&dateFields != 0 && dt.Weekday() != state.weekday {
+		return dt, fmt.Errorf("Weekday %s does not match the parsed date %s", state.weekday, dt.Format(time.DateOnly))
+	}
+
+	return dt, nil
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func daysInYear(year int) int {
+	return time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// Date of the given weekday within an ISO 8601 week where week 1 is the week
+// containing the first Thursday of the year
+func isoWeekDate(year, week int, weekday time.Weekday) time.Time {
+	jan4th := time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC)
+	week1Monday := jan4th.AddDate(0, 0, -((int(jan4th.Weekday()) + 6) % 7))
+	return week1Monday.AddDate(0, 0, (week-1)*7+(int(weekday)+6)%7)
+}
+
+// Date of the given weekday within a week starting on Sunday where week 1 is the
+// week containing January 1st
+func localeWeekDate(year, week int, weekday time.Weekday) time.Time {
+	jan1st := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	week1Sunday := jan1st.AddDate(0, 0, -int(jan1st.Weekday()))
+	return week1Sunday.AddDate(0, 0, (week-1)*7+int(weekday))
+}
+
+// Date of the given weekday within a week of the year where week 1 starts on the first
+// `weekStart` of January and any days before it are in week 0
+func yearWeekDate(year, week int, weekStart, weekday time.Weekday) time.Time {
+	firstWeekStart := dateutils.NextWeekday(weekStart, time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC))
+	return firstWeekStart.AddDate(0, 0, (week-1)*7+(7+int(weekday)-int(weekStart))%7)
+}
+
+// Reads between `minLen` and `maxLen` ASCII digits from the start of the input
+//
+// Returns the value read and the number of bytes consumed
+func parseDigits(input string, minLen, maxLen int) (int, int, error) {
+	val := 0
+	idx := 0
+	for ; idx < len(input) && idx < maxLen; idx++ {
+		char := input[idx]
+		if char < '0' || char > '9' {
+			break
+		}
+		if val > (math.MaxInt-9)/10 {
+			return 0, 0, fmt.Errorf("Number is too large")
+		}
+		val = val*10 + int(char-'0')
+	}
+	if idx < minLen {
+		if minLen == maxLen {
+			return 0, 0, fmt.Errorf("Expected %d digits", minLen)
+		}
+		return 0, 0, fmt.Errorf("Expected at least %d digits", minLen)
+	}
+	return val, idx, nil
+}
+
+// Reads digits as `parseDigits` does allowing an optional leading '+' or '-' sign
+func parseSignedDigits(input string, minLen, maxLen int) (int, int, error) {
+	if len(input) > 0 && (input[0] == '-' || input[0] == '+') {
+		val, consumed, err := parseDigits(input[1:], minLen, maxLen)
+		if input[0] == '-' {
+			val = -val
+		}
+		return val, consumed + 1, err
+	}
+	return parseDigits(input, minLen, maxLen)
+}
+
+// Creates a parse function reading between `minLen` and `maxLen` digits that must fall
+// within the inclusive range `lo` to `hi` before being passed to `set`
+func numberParser(minLen, maxLen, lo, hi int, name string, set func(state *parseState, val int)) func(state *parseState, input string) (int, error) {
+	return func(state *parseState, input string) (int, error) {
+		val, consumed, err := parseDigits(input, minLen, maxLen)
+		if err != nil {
+			return 0, fmt.Errorf("Unable to parse %s: %s", name, err)
+		}
+		if val < lo || val > hi {
+			return 0, fmt.Errorf("%s %d is out of range (%d-%d)", capitalise(name), val, lo, hi)
+		}
+		set(state, val)
+		return consumed, nil
+	}
+}
+
+// Creates a parse function reading a number suffixed with an English ordinal
+// indicator ('st', 'nd', 'rd' or 'th') which is optional when parsing
+func ordinalParser(maxLen, lo, hi int, name string, set func(state *parseState, val int)) func(state *parseState, input string) (int, error) {
+	parseNum := numberParser(1, maxLen, lo, hi, name, set)
+	return func(state *parseState, input string) (int, error) {
+		consumed, err := parseNum(state, input)
+		if err != nil {
+			return 0, err
+		}
+		for _, suffix := range []string{"st", "nd", "rd", "th"} {
+			if hasPrefixFold(input[consumed:], suffix) {
+				return consumed + len(suffix), nil
+			}
+		}
+		return consumed, nil
+	}
+}
+
+// Creates a parse function reading up to `maxLen` digits as the fractional part of a
+// second e.g. "5" and "500" both represent half a second
+func fractionParser(maxLen int) func(state *parseState, input string) (int, error) {
+	return func(state *parseState, input string) (int, error) {
+		val, consumed, err := parseDigits(input, 1, maxLen)
+		if err != nil {
+			return 0, fmt.Errorf("Unable to parse fractional seconds: %s", err)
+		}
+		for range 9 - consumed {
+			val *= 10
+		}
+		state.nano = val
+		return consumed, nil
+	}
+}
+
+// Reads the longest name from the start of the input that matches any of the
+// candidates ignoring case
+//
+// Returns the index of the matched candidate and the number of bytes consumed
+func parseName(input string, candidates []string) (int, int, bool) {
+	matchIdx, matchLen := -1, 0
+	for idx, candidate := range candidates {
+		if len(candidate) > matchLen && hasPrefixFold(input, candidate) {
+			matchIdx, matchLen = idx, len(candidate)
+		}
+	}
+	return matchIdx, matchLen, matchIdx != -1
+}
+
+func hasPrefixFold(str, prefix string) bool {
+	return len(str) >= len(prefix) && strings.EqualFold(str[:len(prefix)], prefix)
+}
+
+func capitalise(str string) string {
+	if str == "" {
+		return str
+	}
+	return strings.ToUpper(str[:1]) + str[1:]
+}
+
+// Parses any representation of a month name in the state's locale; wide,
+// abbreviated or truncated to three characters
+func parseMonthName(state *parseState, input string) (int, error) {
+	candidates := make([]string, 0, 36)
+	months := make([]time.Month, 0, 36)
+	for month := time.January; month <= time.December; month++ {
+		wide := state.locale.MonthWide(month)
+		for _, name := range []string{wide, state.locale.MonthAbbreviated(month), truncate(wide, 3)} {
+			candidates = append(candidates, name)
+			months = append(months, month)
+		}
+	}
+
+	idx, consumed, ok := parseName(input, candidates)
+	if !ok {
+		return 0, fmt.Errorf("Unable to parse month name")
+	}
+	setMonth(state, int(months[idx]))
+	return consumed, nil
+}
+
+// Parses any representation of a weekday name in the state's locale; wide,
+// abbreviated, short or truncated to three characters
+func parseWeekdayName(state *parseState, input string) (int, error) {
+	candidates := make([]string, 0, 28)
+	weekdays := make([]time.Weekday, 0, 28)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		wide := state.locale.WeekdayWide(weekday)
+		for _, name := range []string{wide, state.locale.WeekdayAbbreviated(weekday), state.locale.WeekdayShort(weekday), truncate(wide, 3)} {
+			candidates = append(candidates, name)
+			weekdays = append(weekdays, weekday)
+		}
+	}
+
+	idx, consumed, ok := parseName(input, candidates)
+	if !ok {
+		return 0, fmt.Errorf("Unable to parse weekday name")
+	}
+	setWeekday(state, int(weekdays[idx]))
+	return consumed, nil
+}
+
+// Parses an English meridiem; 'am', 'pm', 'a.m.' or 'p.m.' ignoring case
+func parseMeridiem(state *parseState, input string) (int, error) {
+	idx, consumed, ok := parseName(input, []string{"am", "a.m.", "pm", "p.m."})
+	if !ok {
+		return 0, fmt.Errorf("Unable to parse meridiem")
+	}
+	state.meridiem = meridiemAM
+	if idx > 1 {
+		state.meridiem = meridiemPM
+	}
+	return consumed, nil
+}
+
+// Parses an English era name; abbreviated or in full ignoring case
+func parseEra(state *parseState, input string) (int, error) {
+	idx, consumed, ok := parseName(input, []string{"BC", "Before Christ", "B", "AD", "Anno Domini", "A"})
+	if !ok {
+		return 0, fmt.Errorf("Unable to parse era")
+	}
+	state.bc = idx < 3
+	return consumed, nil
+}
+
+// Parses a time zone offset from UTC in any of the forms 'Z', '±h', '±hh', '±hhmm' or
+// '±hh:mm'
+func parseOffset(state *parseState, input string) (int, error) {
+	if len(input) > 0 && (input[0] == 'Z' || input[0] == 'z') {
+		state.location = time.UTC
+		return 1, nil
+	}
+	if len(input) == 0 || (input[0] != '+' && input[0] != '-') {
+		return 0, fmt.Errorf("Unable to parse time zone offset: expected '+' or '-'")
+	}
+
+	hours, consumed, err := parseDigits(input[1:], 1, 2)
+	if err != nil {
+		return 0, fmt.Errorf("Unable to parse time zone offset: %s", err)
+	}
+	consumed += 1
+
+	minutes := 0
+	rest := input[consumed:]
+	colon := len(rest) > 0 && rest[0] == ':'
+	if colon {
+		rest = rest[1:]
+	}
+	if mins, minsConsumed, err := parseDigits(rest, 2, 2); err == nil {
+		minutes = mins
+		consumed += minsConsumed
+		if colon {
+			consumed += 1
+		}
+	}
+	if hours > 23 || minutes > 59 {
+		return 0, fmt.Errorf("Time zone offset %q is out of range", input[:consumed])
+	}
+
+	offset := (hours*60 + minutes) * 60
+	if input[0] == '-' {
+		offset = -offset
+	}
+	state.location = fixedZone(offset)
+	return consumed, nil
+}
+
+// Parses an abbreviated time zone name such as 'UTC' or 'CEST'
+//
+// Abbreviations are ambiguous so only 'UTC', 'GMT' and 'Z' set the parsed time zone
+// whereas others must be the abbreviation of the local time zone at the parsed date;
+// numeric offsets in place of a name are handled as with `parseOffset`
+func parseZoneAbbreviation(state *parseState, input string) (int, error) {
+	if len(input) > 0 && (input[0] == '+' || input[0] == '-') {
+		return parseOffset(state, input)
+	}
+
+	consumed := 0
+	for consumed < len(input) && isLetter(input[consumed]) {
+		consumed++
+	}
+	if consumed == 0 {
+		return 0, fmt.Errorf("Unable to parse time zone abbreviation")
+	}
+	switch name := strings.ToUpper(input[:consumed]); name {
+	case "UTC", "GMT", "Z":
+		state.location = time.UTC
+	default:
+		state.abbreviation = name
+	}
+	return consumed, nil
+}
+
+func isLetter(char byte) bool {
+	return 'a' <= char && char <= 'z' || 'A' <= char && char <= 'Z'
+}
+
+// Fixed time zone for an offset in seconds using UTC when there is no offset
+func fixedZone(offset int) *time.Location {
+	if offset == 0 {
+		return time.UTC
+	}
+	return time.FixedZone("", offset)
+}
+
+// Creates a parse function reading a signed unix timestamp in multiples of `unit`
+// with optional fractional units e.g. '1746799240.123'
+func unixParser(unit time.Duration) func(state *parseState, input string) (int, error) {
+	return func(state *parseState, input string) (int, error) {
+		val, consumed, err := parseSignedDigits(input, 1, 19)
+		if err != nil {
+			return 0, fmt.Errorf("Unable to parse unix timestamp: %s", err)
+		}
+
+		perSecond := int64(time.Second / unit)
+		nanos := (int64(val) % perSecond) * int64(unit)
+		if rest := input[consumed:]; len(rest) > 1 && rest[0] == '.' {
+			if frac, fracConsumed, err := parseDigits(rest[1:], 1, 9); err == nil {
+				for range 9 - fracConsumed {
+					frac *= 10
+				}
+				fracNanos := int64(frac) * int64(unit) / int64(time.Second)
+				if input[0] == '-' {
+					fracNanos = -fracNanos
+				}
+				nanos += fracNanos
+				consumed += fracConsumed + 1
+			}
+		}
+
+		state.unix = time.Unix(int64(val)/perSecond, nanos)
+		state.fields |= fieldUnix
+		return consumed, nil
+	}
+}
+
+func truncate(str string, length int) string {
+	if len(str) <= length {
+		return str
+	}
+	return str[:length]
+}
+
+// Expands a two digit year to a full year where values above `pivot` are in the 1900s
+// and all others are in the 2000s
+func expandTwoDigitYear(year, pivot int) int {
+	if year > pivot {
+		return year + 1900
+	}
+	return year + 2000
+}
+
+func setYear(state *parseState, year int) {
+	state.year = year
+	state.fields |= fieldYear
+}
+
+func setMonth(state *parseState, month int) {
+	state.month = time.Month(month)
+	state.fields |= fieldMonth
+}
+
+func setDay(state *parseState, day int) {
+	state.day = day
+	state.fields |= fieldDay
+}
+
+func setYearDay(state *parseState, yearDay int) {
+	state.yearDay = yearDay
+	state.fields |= fieldYearDay
+}
+
+func setWeekday(state *parseState, weekday int) {
+	state.weekday = time.Weekday(weekday % 7)
+	state.fields |= fieldWeekday
+}
+
+func setQuarter(state *parseState, quarter int) {
+	state.quarter = quarter
+	state.fields |= fieldQuarter
+}
+
+func setISOYear(state *parseState, year int) {
+	state.isoYear = year
+	state.fields |= fieldISOYear
+}
+
+func setISOWeek(state *parseState, week int) {
+	state.isoWeek = week
+	state.fields |= fieldISOWeek
+}
+
+func setWeekYear(state *parseState, year int) {
+	state.weekYear = year
+	state.fields |= fieldWeekYear
+}
+
+func setYearWeek(state *parseState, week int, weekStart time.Weekday) {
+	state.yearWeek = week
+	state.yearWeekStart = weekStart
+	state.fields |= fieldYearWeek
+}
+
+func setCentury(state *parseState, century int) {
+	state.century = century
+	state.fields |= fieldCentury
+}
+
+func setWeek(state *parseState, week int) {
+	state.week = week
+	state.fields |= fieldWeek
+}
+
+func setHour(state *parseState, hour int) {
+	state.hour = hour
+}
+
+func setMinute(state *parseState, minute int) {
+	state.minute = minute
+}
+
+func setSecond(state *parseState, second int) {
+	state.second = second
+}
+
+// Wraps a parse function to skip any leading whitespace used to pad the value
+func spacePadded(parse func(state *parseState, input string) (int, error)) func(state *parseState, input string) (int, error) {
+	return func(state *parseState, input string) (int, error) {
+		padding := len(input) - len(strings.TrimLeftFunc(input, unicode.IsSpace))
+		consumed, err := parse(state, input[padding:])
+		return padding + consumed, err
+	}
+}
+
+// Creates a parse function reading a year of between `minLen` and `maxLen` digits
+// with an optional leading '+' or '-' sign
+func yearParser(minLen, maxLen int) func(state *parseState, input string) (int, error) {
+	return func(state *parseState, input string) (int, error) {
+		year, consumed, err := parseSignedDigits(input, minLen, maxLen)
+		if err != nil {
+			return 0, fmt.Errorf("Unable to parse year: %s", err)
+		}
+		setYear(state, year)
+		return consumed, nil
+	}
+}
+
+// Reference date time with distinct values for each component used to work out the
+// layout of localised formats
+var localisedReference = time.Date(2033, time.November, 22, 15, 44, 55, 0, time.UTC)
+
+// Creates a parse function for a localised format such as `FmtDateShort`
+//
+// The reference date time is formatted in the state's locale and each component found
+// in the output is mapped back to a token that is able to parse it; everything else
+// is matched literally
+func localisedParser(expand func(dt time.Time, locale locales.Translator) string) func(state *parseState, input string) (int, error) {
+	return func(state *parseState, input string) (int, error) {
+		tokens := localisedTokens(expand(localisedReference, state.locale), state.locale)
+		offset, err := matchTokens(state, input, tokens)
+		if err != nil {
+			return 0, fmt.Errorf("Unable to parse localised format: %s", err)
+		}
+		return offset, nil
+	}
+}
+
+// Maps a formatted reference date time back into the tokens it is made up of
+func localisedTokens(formatted string, locale locales.Translator) []layoutToken {
+	ref := localisedReference
+	components := []struct {
+		text  string
+		token FormatToken[string]
+	}{
+		{locale.WeekdayWide(ref.Weekday()), FormatToken[string]{parse: parseWeekdayName}},
+		{locale.MonthWide(ref.Month()), FormatToken[string]{parse: parseMonthName}},
+		{locale.WeekdayAbbreviated(ref.Weekday()), FormatToken[string]{parse: parseWeekdayName}},
+		{locale.MonthAbbreviated(ref.Month()), FormatToken[string]{parse: parseMonthName}},
+		{"2033", FormatToken[string]{parse: yearParser(1, 4)}},
+		{"UTC", FormatToken[string]{parse: parseZoneAbbreviation}},
+		{"p.m.", FormatToken[string]{parse: parseMeridiem}},
+		{"pm", FormatToken[string]{parse: parseMeridiem}},
+		{"PM", FormatToken[string]{parse: parseMeridiem}},
+		{"33", FormatToken[string]{parse: numberParser(2, 2, 0, 99, "year", func(state *parseState, year int) {
+			setYear(state, expandTwoDigitYear(year, 68))
+		})}},
+		{"22", FormatToken[string]{parse: numberParser(1, 2, 1, 31, "day of month", setDay)}},
+		{"11", FormatToken[string]{parse: numberParser(1, 2, 1, 12, "month", setMonth)}},
+		{"15", FormatToken[string]{parse: numberParser(1, 2, 0, 23, "hour", setHour)}},
+		{"44", FormatToken[string]{parse: numberParser(1, 2, 0, 59, "minute", setMinute)}},
+		{"55", FormatToken[string]{parse: numberParser(1, 2, 0, 59, "second", setSecond)}},
+		{"03", FormatToken[string]{parse: numberParser(1, 2, 1, 12, "hour", setHour)}},
+		{"3", FormatToken[string]{parse: numberParser(1, 2, 1, 12, "hour", setHour)}},
+	}
+
+	var tokens []layoutToken
+	idx := 0
+	for idx < len(formatted) {
+		matched := false
+		for _, component := range components {
+			if component.text != "" && strings.HasPrefix(formatted[idx:], component.text) {
+				tokens = append(tokens, layoutToken{raw: component.text, def: &component.token})
+				idx += len(component.text)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		_, charLen := utf8.DecodeRuneInString(formatted[idx:])
+		tokens = appendLiteral(tokens, formatted[idx:idx+charLen])
+		idx += charLen
+	}
+	return tokens
+}
+
+// Parses an IANA time zone name such as 'Europe/London' or 'UTC'
+func parseZoneName(state *parseState, input string) (int, error) {
+	consumed := 0
+	for consumed < len(input) {
+		char := input[consumed]
+		if !isLetter(char) && !('0' <= char && char <= '9') && !strings.ContainsRune("/_+-", rune(char)) {
+			break
+		}
+		consumed++
+	}
+	if consumed == 0 {
+		return 0, fmt.Errorf("Unable to parse time zone name")
+	}
+
+	location, err := time.LoadLocation(input[:consumed])
+	if err != nil {
+		return 0, fmt.Errorf("Unable to parse time zone name: %s", err)
+	}
+	state.location = location
+	return consumed, nil
+}
+
+func setMillisecond(state *parseState, millisecond int) {
+	state.nano = millisecond * int(time.Millisecond)
+}
+
+// Matches any amount of whitespace including none
+func parseWhitespace(state *parseState, input string) (int, error) {
+	return len(input) - len(strings.TrimLeftFunc(input, unicode.IsSpace)), nil
+}
@@ -15,8 +15,10 @@ type FormatToken[T any] struct {
 	// Description of what the token represents
 	Desc string
 	// Equivalent string for token given a `time.Time`
-	expand  func(dt time.Time, locale locales.Translator) string
-	parse   func(dt *time.Time, str *string) (int, error)
+	expand func(dt time.Time, locale locales.Translator) string
+	// Reads the token from the start of the input into the parse state returning the
+	// number of bytes consumed
+	parse   func(state *parseState, input string) (int, error)
 	aliases []T
 }
 
@@ -43,6 +45,29 @@ type DateDescriptor interface {
 	TokenDescTokenFormatter(tokenFmt func(format string, a ...any) string) string
 	// Mapping between any token and it's corresponding meta data
 	TokenMap() TokenMap
+	// Lists every supported token as written in a layout sorted by token
+	Tokens() []TokenInfo
+}
+
+// Token as written in a layout along with its description and aliases
+type TokenInfo struct {
+	Token   string   `json:"token"`
+	Desc    string   `json:"description"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// Lists the tokens of a token map sorted by token with `prefix` prepended to each token
+// and alias
+func tokenInfo(tokenDef TokenMap, prefix string) []TokenInfo {
+	tokens := []TokenInfo{}
+	for _, token := range slices.Sorted(maps.Keys(tokenDef)) {
+		info := TokenInfo{Token: prefix + token, Desc: tokenDef[token].Desc}
+		for _, alias := range tokenDef[token].aliases {
+			info.Aliases = append(info.Aliases, prefix+alias)
+		}
+		tokens = append(tokens, info)
+	}
+	return tokens
 }
 
 // Allows formatting date times into other data types
@@ -53,8 +78,8 @@ type DateFormatter interface {
 
 // Allows parsing data types into date times
 type DateParser interface {
-	// Parses a time string according to the provided format
-	Parse(input, format string) (time.Time, error)
+	// Parses a time string according to the provided format and locale
+	Parse(input, format string, locale locales.Translator) (time.Time, error)
 }
 
 type DateHandler interface {
@@ -66,7 +91,7 @@ type DateHandler interface {
 // Simplest date handler type wrapping functionality defined elsewhere that works with tokens
 type DateHandlerTokenWrapper struct {
 	format   func(dt time.Time, locale locales.Translator, formatStr string) string
-	parse    func(input, format string) (time.Time, error)
+	parse    func(input, format string, locale locales.Translator) (time.Time, error)
 	tokenDef TokenMap
 	prefix   rune
 }
@@ -75,12 +100,20 @@ func (formatter *DateHandlerTokenWrapper) TokenMap() TokenMap {
 	return expandTokenMap(&formatter.tokenDef)
 }
 
+func (formatter *DateHandlerTokenWrapper) Tokens() []TokenInfo {
+	prefix := ""
+	if formatter.prefix != 0 {
+		prefix = string(formatter.prefix)
+	}
+	return tokenInfo(formatter.tokenDef, prefix)
+}
+
 func (formatter *DateHandlerTokenWrapper) Format(dt time.Time, locale locales.Translator, str *string) string {
 	return formatter.format(dt, locale, *str)
 }
 
-func (formatter *DateHandlerTokenWrapper) Parse(input, format string) (time.Time, error) {
-	return formatter.parse(input, format)
+func (formatter *DateHandlerTokenWrapper) Parse(input, format string, locale locales.Translator) (time.Time, error) {
+	return formatter.parse(input, format, locale)
 }
 
 func (formatter *DateHandlerTokenWrapper) TokenDescTokenFormatter(tokenFmt func(format string, a ...any) string) string {
@@ -124,3 +157,34 @@ func numberSuffixed(num int) string {
 		return numstr + "th"
 	}
 }
+
+// Formats the time zone offset of the date time as hours and minutes with the given
+// separator between them
+//
+// Minutes are left out when zero unless `minutes` is set and UTC is written as `zero`
+// when given
+func formatOffset(dt time.Time, separator string, minutes bool, zero string) string {
+	_, offset := dt.Zone()
+	if offset == 0 && zero != "" {
+		return zero
+	}
+
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	hours, mins := offset/3600, offset/60%60
+	if !minutes && mins == 0 {
+		return fmt.Sprintf("%c%02d", sign, hours)
+	}
+	return fmt.Sprintf("%c%02d%s%02d", sign, hours, separator, mins)
+}
+
+// Hour of a clock that shows `hours` in place of 0 e.g. 12 for a 12 hour clock
+func clockHour(hour, hours int) int {
+	if hour == 0 {
+		return hours
+	}
+	return hour
+}
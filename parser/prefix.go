@@ -6,6 +6,8 @@ import (
 	"slices"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-playground/locales"
 )
@@ -14,68 +16,158 @@ import (
 //
 // e.g. C based `strptime` function tokens: '%c', '%Oy' etc.
 type DateHandlerPrefix struct {
-	Prefix     rune
+	Prefix rune
+	// Characters written between the prefix and a token changing how it is output
+	// e.g. glibc's '%-d' for the day without padding
+	flags      map[rune]tokenFlag
 	tokenDef   TokenMap
 	tokenGraph *TokenGraphNode[FormatToken[string]]
 }
 
-func (formatter *DateHandlerPrefix) Parse(input, format string) (time.Time, error) {
-	dt := time.Unix(0, 0).UTC()
-
-	tokenNode := formatter.tokenGraph
-	interpretMode := false
-	offsetIdx := 0
-	var startIdx int
+// Modifier changing the output of the token following it
+type tokenFlag struct {
+	Desc  string
+	apply func(output string) string
+}
 
-	for idx, char := range format {
-		if char == formatter.Prefix && !interpretMode {
-			interpretMode = true
-			startIdx = idx + offsetIdx
-			continue
+// Reads any flags from the start of the layout returning them with their length in bytes
+func (formatter *DateHandlerPrefix) readFlags(layout string) ([]tokenFlag, int) {
+	var flags []tokenFlag
+	flagsLen := 0
+	for _, char := range layout {
+		flag, isFlag := formatter.flags[char]
+		if !isFlag {
+			break
 		}
-		if !interpretMode {
-			continue
+		flags = append(flags, flag)
+		flagsLen += utf8.RuneLen(char)
+	}
+	return flags, flagsLen
+}
+
+// Wraps a token so that its output is changed by each of the flags in turn
+//
+// Flags only change the output so the token is parsed as it would be without them
+func flaggedToken(token FormatToken[string], flags []tokenFlag) FormatToken[string] {
+	if len(flags) == 0 || token.expand == nil {
+		return token
+	}
+	expand := token.expand
+	for _, flag := range flags {
+		token.Desc += ", " + flag.Desc
+	}
+	token.expand = func(dt time.Time, locale locales.Translator) string {
+		output := expand(dt, locale)
+		for _, flag := range flags {
+			output = flag.apply(output)
 		}
+		return output
+	}
+	return token
+}
 
-		if node, hasToken := tokenNode.children[char]; hasToken {
-			tokenNode = node
+// Parses the input according to the layout where any date components missing from the
+// layout are taken from the unix epoch in the local time zone
+//
+// As with `strptime` whitespace in the layout matches any amount of whitespace in the
+// input including none
+func (formatter *DateHandlerPrefix) Parse(input, format string, locale locales.Translator) (time.Time, error) {
+	base := time.Date(1970, time.January, 1, 0, 0, 0, 0, time.Local)
+	return parseLayout(input, format, formatter.parseLayoutTokens(format), locale, base)
+}
+
+// Parses the start of the input according to the layout returning the number of bytes
+// consumed so that dates can be found within longer text
+func (formatter *DateHandlerPrefix) ParsePrefix(input, format string, locale locales.Translator) (time.Time, int, error) {
+	base := time.Date(1970, time.January, 1, 0, 0, 0, 0, time.Local)
+	return parseLayoutPrefix(input, format, formatter.parseLayoutTokens(format), locale, base)
+}
+
+// Matches whitespace within a layout being parsed
+var whitespaceToken = FormatToken[string]{
+	Desc:  "Any amount of whitespace",
+	parse: parseWhitespace,
+}
+
+// Tokenises a layout for parsing where each run of whitespace within literal sections
+// is replaced with a token matching any amount of whitespace
+func (formatter *DateHandlerPrefix) parseLayoutTokens(layout string) []layoutToken {
+	var tokens []layoutToken
+	for _, token := range formatter.tokenise(layout) {
+		if token.def != nil || token.unknown {
+			tokens = append(tokens, token)
 			continue
 		}
 
-		if parseFunc := tokenNode.value.parse; parseFunc != nil {
-			slice := input[startIdx:]
-			offset, err := parseFunc(&dt, &slice)
-			if err != nil {
-				return dt, err
+		literal := token.literal
+		for len(literal) > 0 {
+			spaceIdx := strings.IndexFunc(literal, unicode.IsSpace)
+			if spaceIdx == -1 {
+				tokens = appendLiteral(tokens, literal)
+				break
+			}
+			if spaceIdx > 0 {
+				tokens = appendLiteral(tokens, literal[:spaceIdx])
 			}
-			offsetIdx += offset - 1
+			rest := strings.TrimLeftFunc(literal[spaceIdx:], unicode.IsSpace)
+			tokens = append(tokens, layoutToken{raw: literal[spaceIdx : len(literal)-len(rest)], def: &whitespaceToken})
+			literal = rest
 		}
-
-		tokenNode = formatter.tokenGraph
-		interpretMode = char == formatter.Prefix
 	}
+	return tokens
+}
+
+// Splits a layout into its tokens and literal sections
+//
+// Prefixes not followed by a known token are marked as unknown
+func (formatter *DateHandlerPrefix) tokenise(layout string) []layoutToken {
+	var tokens []layoutToken
+	isToken := func(token FormatToken[string]) bool { return token.expand != nil }
+	prefixLen := utf8.RuneLen(formatter.Prefix)
+
+	idx := 0
+	for idx < len(layout) {
+		char, charLen := utf8.DecodeRuneInString(layout[idx:])
 
-	if parseFunc := tokenNode.value.parse; parseFunc != nil {
-		slice := input[startIdx:]
-		_, err := parseFunc(&dt, &slice)
-		if err != nil {
-			return dt, err
+		if char == formatter.Prefix {
+			flags, flagsLen := formatter.readFlags(layout[idx+prefixLen:])
+			token, tokenLen := formatter.tokenGraph.longestToken(layout[idx+prefixLen+flagsLen:], isToken)
+			if tokenLen > 0 {
+				token = flaggedToken(token, flags)
+				tokens = append(tokens, layoutToken{raw: layout[idx : idx+prefixLen+flagsLen+tokenLen], def: &token})
+				idx += prefixLen + flagsLen + tokenLen
+				continue
+			}
+
+			_, unknownLen := utf8.DecodeRuneInString(layout[idx+prefixLen:])
+			tokens = append(tokens, layoutToken{raw: layout[idx : idx+prefixLen+unknownLen], unknown: true})
+			idx += prefixLen + unknownLen
+			continue
 		}
+
+		tokens = appendLiteral(tokens, layout[idx:idx+charLen])
+		idx += charLen
 	}
 
-	return dt, nil
+	return tokens
 }
 
 func (formatter *DateHandlerPrefix) TokenMap() TokenMap {
 	return expandTokenMap(&formatter.tokenDef)
 }
 
+func (formatter *DateHandlerPrefix) Tokens() []TokenInfo {
+	return tokenInfo(formatter.tokenDef, string(formatter.Prefix))
+}
+
 func (formatter DateHandlerPrefix) Format(dt time.Time, locale locales.Translator, str *string) string {
 	var formattedDate strings.Builder
 	var tokens strings.Builder
 
 	tokenNode := formatter.tokenGraph
 	interpretMode := false
+	var flags []tokenFlag
+	var flagChars strings.Builder
 
 	for _, char := range *str {
 		if char == formatter.Prefix && !interpretMode {
@@ -87,19 +179,27 @@ func (formatter DateHandlerPrefix) Format(dt time.Time, locale locales.Translato
 			continue
 		}
 
+		if flag, isFlag := formatter.flags[char]; isFlag && tokens.Len() == 0 {
+			flags = append(flags, flag)
+			flagChars.WriteRune(char)
+			continue
+		}
+
 		if node, hasToken := tokenNode.children[char]; hasToken {
 			tokens.WriteRune(char)
 			tokenNode = node
 			continue
 		}
 
-		if formatFunc := tokenNode.value.expand; formatFunc != nil {
+		if formatFunc := flaggedToken(tokenNode.value, flags).expand; formatFunc != nil {
 			formattedDate.WriteString(formatFunc(dt, locale))
 		} else {
-			formattedDate.WriteString(tokens.String())
+			formattedDate.WriteString(flagChars.String() + tokens.String())
 		}
 
 		tokens.Reset()
+		flags = nil
+		flagChars.Reset()
 		interpretMode = false
 		tokenNode = formatter.tokenGraph
 
@@ -111,10 +211,10 @@ func (formatter DateHandlerPrefix) Format(dt time.Time, locale locales.Translato
 		formattedDate.WriteRune(char)
 	}
 
-	if formatFunc := tokenNode.value.expand; formatFunc != nil {
+	if formatFunc := flaggedToken(tokenNode.value, flags).expand; formatFunc != nil {
 		formattedDate.WriteString(formatFunc(dt, locale))
 	} else {
-		formattedDate.WriteString(tokens.String())
+		formattedDate.WriteString(flagChars.String() + tokens.String())
 	}
 
 	return formattedDate.String()
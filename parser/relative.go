@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gitlab.com/monokuro/era/dateutils"
+	"gitlab.com/monokuro/era/localiser"
+
+	"github.com/go-playground/locales"
+)
+
+// Describes a date time relative to a reference e.g. "3 hours ago" or "in 2 days"
+//
+// Follows the thresholds of moment's `fromNow` where each unit is rounded and used
+// until it reaches the threshold of the next: under 45 seconds is a few seconds, 45
+// minutes an hour, 22 hours a day, 26 days a month and 11 months a year
+func FormatRelative(dt time.Time, reference time.Time, locale locales.Translator) string {
+	phrases := localiser.Relative(locale)
+
+	diff := dt.Sub(reference)
+	wrapper := phrases.Future
+	// As with moment the same time is in the past
+	if diff <= 0 {
+		wrapper = phrases.Past
+		diff = -diff
+	}
+
+	seconds := math.Round(diff.Seconds())
+	minutes := math.Round(diff.Minutes())
+	hours := math.Round(diff.Hours())
+	days := math.Round(diff.Hours() / 24)
+	// Average length of a month over the 400 year Gregorian cycle
+	monthsExact := diff.Hours() / 24 * 4800 / 146097
+	months := math.Round(monthsExact)
+	years := math.Round(monthsExact / 12)
+
+	amount := func(unit string, count float64) string {
+		if count <= 1 {
+			return phrases.Single[unit]
+		}
+		return localiser.FormatUnit(locale, unit, count, 0)
+	}
+
+	var description string
+	switch {
+	case seconds < 45:
+		description = phrases.FewSeconds
+	case minutes < 45:
+		description = amount("minute", minutes)
+	case hours < 22:
+		description = amount("hour", hours)
+	case days < 26:
+		description = amount("day", days)
+	case months < 11:
+		description = amount("month", months)
+	default:
+		description = amount("year", years)
+	}
+
+	return fmt.Sprintf(wrapper, description)
+}
+
+// Describes a date time by its calendar day relative to a reference e.g. "yesterday at
+// 14:00" or "last Friday at 09:30" following moment's `calendar`
+//
+// Dates more than a week away are formatted as a short localised date
+func FormatCalendar(dt time.Time, reference time.Time, locale locales.Translator) string {
+	phrases := localiser.Relative(locale)
+
+	reference = reference.In(dt.Location())
+	day := dateutils.DayStart(dt)
+	referenceDay := dateutils.DayStart(reference)
+	// Days are counted by calendar date so daylight saving changes are ignored
+	days := int(math.Round(day.Sub(referenceDay).Hours() / 24))
+
+	timeOfDay := locale.FmtTimeShort(dt)
+	weekday := locale.WeekdayWide(dt.Weekday())
+	switch {
+	case days < -6:
+		return locale.FmtDateShort(dt)
+	case days < -1:
+		return fmt.Sprintf(phrases.LastWeek, weekday, timeOfDay)
+	case days < 0:
+		return fmt.Sprintf(phrases.Yesterday, timeOfDay)
+	case days < 1:
+		return fmt.Sprintf(phrases.Today, timeOfDay)
+	case days < 2:
+		return fmt.Sprintf(phrases.Tomorrow, timeOfDay)
+	case days < 7:
+		return fmt.Sprintf(phrases.NextWeek, weekday, timeOfDay)
+	}
+	return locale.FmtDateShort(dt)
+}
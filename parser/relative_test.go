@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/locales/en_GB"
+	"github.com/go-playground/locales/es_ES"
+	"github.com/go-playground/locales/fr_FR"
+)
+
+func TestFormatRelative(t *testing.T) {
+	reference := time.Date(2025, 5, 9, 15, 0, 0, 0, time.UTC)
+
+	scenarios := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{offset: 0, want: "a few seconds ago"},
+		{offset: -44 * time.Second, want: "a few seconds ago"},
+		{offset: -45 * time.Second, want: "a minute ago"},
+		{offset: 90 * time.Second, want: "in 2 minutes"},
+		{offset: -44 * time.Minute, want: "44 minutes ago"},
+		{offset: 45 * time.Minute, want: "in an hour"},
+		{offset: -3 * time.Hour, want: "3 hours ago"},
+		{offset: 21 * time.Hour, want: "in 21 hours"},
+		{offset: -22 * time.Hour, want: "a day ago"},
+		{offset: 48 * time.Hour, want: "in 2 days"},
+		{offset: -25 * 24 * time.Hour, want: "25 days ago"},
+		{offset: 26 * 24 * time.Hour, want: "in a month"},
+		{offset: -100 * 24 * time.Hour, want: "3 months ago"},
+		{offset: 320 * 24 * time.Hour, want: "in a year"},
+		{offset: -800 * 24 * time.Hour, want: "2 years ago"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.want, func(t *testing.T) {
+			t.Parallel()
+			got := FormatRelative(reference.Add(testCase.offset), reference, en_GB.New())
+			if got != testCase.want {
+				t.Errorf("Expected %s to be formatted as '%s'\nGot: '%s'", testCase.offset, testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatRelativeLocale(t *testing.T) {
+	reference := time.Date(2025, 5, 9, 15, 0, 0, 0, time.UTC)
+
+	if got := FormatRelative(reference.Add(-3*time.Hour), reference, fr_FR.New()); got != "il y a 3 heures" {
+		t.Errorf("Expected 'il y a 3 heures'\nGot: '%s'", got)
+	}
+	if got := FormatRelative(reference.Add(time.Hour), reference, es_ES.New()); got != "en una hora" {
+		t.Errorf("Expected 'en una hora'\nGot: '%s'", got)
+	}
+	if got := FormatRelative(reference.Add(-48*time.Hour), reference, es_ES.New()); got != "hace 2 días" {
+		t.Errorf("Expected 'hace 2 días'\nGot: '%s'", got)
+	}
+}
+
+func TestFormatCalendar(t *testing.T) {
+	// Friday
+	reference := time.Date(2025, 5, 9, 15, 0, 0, 0, time.UTC)
+
+	scenarios := []struct {
+		dt   time.Time
+		want string
+	}{
+		{dt: time.Date(2025, 5, 9, 9, 30, 0, 0, time.UTC), want: "today at 09:30"},
+		{dt: time.Date(2025, 5, 8, 14, 0, 0, 0, time.UTC), want: "yesterday at 14:00"},
+		{dt: time.Date(2025, 5, 10, 0, 0, 0, 0, time.UTC), want: "tomorrow at 00:00"},
+		{dt: time.Date(2025, 5, 5, 8, 0, 0, 0, time.UTC), want: "last Monday at 08:00"},
+		{dt: time.Date(2025, 5, 14, 18, 45, 0, 0, time.UTC), want: "Wednesday at 18:45"},
+		{dt: time.Date(2025, 5, 16, 12, 0, 0, 0, time.UTC), want: "16/05/2025"},
+		{dt: time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC), want: "01/05/2025"},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.want, func(t *testing.T) {
+			t.Parallel()
+			got := FormatCalendar(testCase.dt, reference, en_GB.New())
+			if got != testCase.want {
+				t.Errorf("Expected %s to be formatted as '%s'\nGot: '%s'", testCase.dt, testCase.want, got)
+			}
+		})
+	}
+}
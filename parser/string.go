@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-playground/locales"
 )
@@ -14,25 +15,145 @@ import (
 // characters
 type DateHandlerString struct {
 	escapeChars []rune
-	tokenDef    TokenMap
-	tokenGraph  *TokenGraphNode[FormatToken[string]]
+	// A doubled escape character stands for the character itself both within and
+	// outside of escaped sections e.g. Java's "'o''clock'"
+	doubledEscape bool
+	// Characters that each escape the text up to their next occurrence e.g. .NET's
+	// "'" and '"'
+	quoteChars []rune
+	// Character escaping the single character following it e.g. .NET's '\'
+	escapePrefix rune
+	// Characters opening and closing sections that are optional when parsing
+	optionalChars []rune
+	// Runs of a repeated character are read as a single token e.g. luxon's 'yyy' is an
+	// unknown token rather than 'yy' followed by 'y'
+	runTokens bool
+	// ASCII letters are reserved for tokens so unknown letters outside of escaped
+	// sections are an error rather than literal text e.g. Java's 'W'
+	reservedLetters bool
+	// Layouts written in place of a whole layout string e.g. .NET's standard format 'o'
+	standardLayouts map[string]standardLayout
+	tokenDef        TokenMap
+	tokenGraph      *TokenGraphNode[FormatToken[string]]
+}
+
+// Layout used in place of a single character layout string
+type standardLayout struct {
+	Desc   string
+	layout string
+	// Date times are converted to UTC when formatting and read as UTC when parsing
+	utc bool
+}
+
+// Expands a standard layout returning the layout itself when it is not one
+//
+// Formatters with standard layouts read every single character layout as one so an
+// unknown character is an error e.g. .NET's 'Q'
+func (formatter *DateHandlerString) expandLayout(layout string) (string, bool, error) {
+	if standard, ok := formatter.standardLayouts[layout]; ok {
+		return standard.layout, standard.utc, nil
+	}
+	if len(formatter.standardLayouts) > 0 && utf8.RuneCountInString(layout) == 1 {
+		return layout, false, fmt.Errorf("%q is not a standard layout, prefix a single custom token with '%%' e.g. '%%d'", layout)
+	}
+	if formatter.reservedLetters {
+		for _, token := range formatter.tokenise(layout) {
+			if token.def != nil || token.escaped {
+				continue
+			}
+			if idx := strings.IndexFunc(token.literal, isASCIILetter); idx != -1 {
+				return layout, false, fmt.Errorf("%q is not a supported pattern letter, escape literal text within single quotes", token.literal[idx])
+			}
+		}
+	}
+	return layout, false, nil
+}
+
+func isASCIILetter(char rune) bool {
+	return ('a' <= char && char <= 'z') || ('A' <= char && char <= 'Z')
+}
+
+// Reports layouts the formatter cannot read such as unknown standard layouts or
+// unknown Java pattern letters
+func (formatter *DateHandlerString) ValidateLayout(layout string) error {
+	_, _, err := formatter.expandLayout(layout)
+	return err
 }
 
 func (formatter *DateHandlerString) TokenMap() TokenMap {
 	return expandTokenMap(&formatter.tokenDef)
 }
 
+func (formatter *DateHandlerString) Tokens() []TokenInfo {
+	return tokenInfo(formatter.tokenDef, "")
+}
+
+// Formats the date time according to the layout
+//
+// Layouts rejected by ValidateLayout format as an empty string
 func (formatter *DateHandlerString) Format(dt time.Time, locale locales.Translator, str *string) string {
 	var formattedDate strings.Builder
-	var tokens strings.Builder
 
-	tokenNode := formatter.tokenGraph
+	layout, utc, err := formatter.expandLayout(*str)
+	if err != nil {
+		return ""
+	}
+	if utc {
+		dt = dt.UTC()
+	}
 
-	escapeSupport := len(formatter.escapeChars) > 0
-	escapeMode := false
-	var escapeStartChar rune
-	var escapeEndChar rune
+	for _, token := range formatter.tokenise(layout) {
+		if token.def != nil {
+			formattedDate.WriteString(token.def.expand(dt, locale))
+		} else {
+			formattedDate.WriteString(token.literal)
+		}
+	}
+
+	return formattedDate.String()
+}
+
+// Parses the input according to the layout where any date components missing from the
+// layout are taken from the current date
+//
+// Layouts with optional sections are tried with as many of them included as possible
+func (formatter *DateHandlerString) Parse(input, format string, locale locales.Translator) (time.Time, error) {
+	layout, utc, err := formatter.expandLayout(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+	base := time.Now()
+	if utc {
+		base = base.UTC()
+	}
 
+	return parseLayout(input, format, formatter.tokenise(layout), locale, base)
+}
+
+// Parses the start of the input according to the layout returning the number of bytes
+// consumed so that dates can be found within longer text
+func (formatter *DateHandlerString) ParsePrefix(input, format string, locale locales.Translator) (time.Time, int, error) {
+	layout, utc, err := formatter.expandLayout(format)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	base := time.Now()
+	if utc {
+		base = base.UTC()
+	}
+
+	return parseLayoutPrefix(input, format, formatter.tokenise(layout), locale, base)
+}
+
+// Splits a layout into its tokens and literal sections
+//
+// The longest known token is always matched first so 'MMMM' is a single token rather
+// than two 'MM' tokens whereas unknown runs like 'YYY' are split into 'YY' and 'Y'
+func (formatter *DateHandlerString) tokenise(layout string) []layoutToken {
+	var tokens []layoutToken
+
+	var escapeStartChar, escapeEndChar rune
+	escapeSupport := len(formatter.escapeChars) > 0
 	if escapeSupport {
 		escapeStartChar = formatter.escapeChars[0]
 		escapeEndChar = escapeStartChar
@@ -41,48 +162,144 @@ func (formatter *DateHandlerString) Format(dt time.Time, locale locales.Translat
 		}
 	}
 
-	for _, char := range *str {
-		if escapeSupport && char == escapeEndChar && escapeMode {
-			escapeMode = false
+	isToken := func(token FormatToken[string]) bool { return token.expand != nil }
+
+	idx := 0
+	for idx < len(layout) {
+		char, charLen := utf8.DecodeRuneInString(layout[idx:])
+
+		if escapeSupport && formatter.doubledEscape && char == escapeStartChar {
+			token := formatter.tokeniseDoubledEscape(layout[idx:], escapeStartChar)
+			tokens = append(tokens, token)
+			idx += len(token.raw)
 			continue
-		} else if escapeSupport && char == escapeStartChar {
-			escapeMode = true
+		}
+
+		if escapeSupport && char == escapeStartChar {
+			escaped := layout[idx+charLen:]
+			escapedLen := strings.IndexRune(escaped, escapeEndChar)
+			rawLen := charLen + escapedLen + utf8.RuneLen(escapeEndChar)
+			unterminated := escapedLen == -1
+			if unterminated {
+				// Unterminated escape sequences run until the end of the layout
+				escapedLen = len(escaped)
+				rawLen = len(layout) - idx
+			}
+			// Repeated start characters within an escaped section are ignored
+			tokens = append(tokens, layoutToken{
+				raw:          layout[idx : idx+rawLen],
+				literal:      strings.ReplaceAll(escaped[:escapedLen], string(escapeStartChar), ""),
+				escaped:      true,
+				unterminated: unterminated,
+			})
+			idx += rawLen
 			continue
 		}
 
-		if node, hasToken := tokenNode.children[char]; hasToken && !escapeMode {
-			tokens.WriteRune(char)
-			tokenNode = node
+		if slices.Contains(formatter.quoteChars, char) {
+			token := formatter.tokeniseQuoted(layout[idx:], char)
+			tokens = append(tokens, token)
+			idx += len(token.raw)
 			continue
 		}
 
-		if formatFunc := tokenNode.value.expand; formatFunc != nil {
-			formattedDate.WriteString(formatFunc(dt, locale))
-		} else {
-			formattedDate.WriteString(tokens.String())
+		if formatter.escapePrefix != 0 && char == formatter.escapePrefix {
+			_, escapedLen := utf8.DecodeRuneInString(layout[idx+charLen:])
+			tokens = append(tokens, layoutToken{
+				raw:          layout[idx : idx+charLen+escapedLen],
+				literal:      layout[idx+charLen : idx+charLen+escapedLen],
+				escaped:      true,
+				unterminated: escapedLen == 0,
+			})
+			idx += charLen + escapedLen
+			continue
 		}
-		tokens.Reset()
 
-		tokenNode = formatter.tokenGraph
-		if node, hasToken := formatter.tokenGraph.children[char]; hasToken && !escapeMode {
-			tokenNode = node
+		if len(formatter.optionalChars) > 1 && (char == formatter.optionalChars[0] || char == formatter.optionalChars[1]) {
+			tokens = append(tokens, layoutToken{
+				raw:           layout[idx : idx+charLen],
+				optionalStart: char == formatter.optionalChars[0],
+				optionalEnd:   char == formatter.optionalChars[1],
+			})
+			idx += charLen
 			continue
 		}
 
-		formattedDate.WriteRune(char)
+		// Text read as far along the token graph as it goes that does not end on a token
+		// is output as is e.g. moment's 'YYY'
+		run := layout[idx:]
+		if formatter.runTokens {
+			run = run[:len(run)-len(strings.TrimLeft(run, string(char)))]
+		}
+		token, walked := formatter.tokenGraph.walk(run)
+		if formatter.runTokens && walked < len(run) {
+			token, walked = FormatToken[string]{}, len(run)
+		}
+		if walked > 0 {
+			if isToken(token) {
+				tokens = append(tokens, layoutToken{raw: run[:walked], def: &token})
+			} else {
+				tokens = appendLiteral(tokens, run[:walked])
+			}
+			idx += walked
+			continue
+		}
+
+		tokens = appendLiteral(tokens, layout[idx:idx+charLen])
+		idx += charLen
 	}
 
-	if formatFunc := tokenNode.value.expand; formatFunc != nil {
-		formattedDate.WriteString(formatFunc(dt, locale))
-	} else {
-		formattedDate.WriteString(tokens.String())
+	return tokens
+}
+
+// Reads an escaped section from the start of the layout where a doubled escape
+// character stands for the character itself, including when outside of a section
+func (formatter *DateHandlerString) tokeniseDoubledEscape(layout string, escapeChar rune) layoutToken {
+	escapeStr := string(escapeChar)
+	escapeLen := len(escapeStr)
+	if strings.HasPrefix(layout[escapeLen:], escapeStr) {
+		return layoutToken{raw: layout[:2*escapeLen], literal: escapeStr, escaped: true}
 	}
 
-	return formattedDate.String()
+	var literal strings.Builder
+	idx := escapeLen
+	for idx < len(layout) {
+		char, charLen := utf8.DecodeRuneInString(layout[idx:])
+		if char != escapeChar {
+			literal.WriteRune(char)
+			idx += charLen
+			continue
+		}
+		if !strings.HasPrefix(layout[idx+charLen:], escapeStr) {
+			idx += charLen
+			return layoutToken{raw: layout[:idx], literal: literal.String(), escaped: true}
+		}
+		literal.WriteString(escapeStr)
+		idx += 2 * charLen
+	}
+	// Unterminated escape sequences run until the end of the layout
+	return layoutToken{raw: layout, literal: literal.String(), escaped: true, unterminated: true}
 }
 
-func (formatter *DateHandlerString) Parse(input, format string) (time.Time, error) {
-	return time.Now(), nil
+// Reads a quoted section from the start of the layout up to the next occurrence of
+// the quote where the escape prefix may be used to include the quote itself
+func (formatter *DateHandlerString) tokeniseQuoted(layout string, quote rune) layoutToken {
+	var literal strings.Builder
+	idx := utf8.RuneLen(quote)
+	for idx < len(layout) {
+		char, charLen := utf8.DecodeRuneInString(layout[idx:])
+		idx += charLen
+		if char == quote {
+			return layoutToken{raw: layout[:idx], literal: literal.String(), escaped: true}
+		}
+		if formatter.escapePrefix != 0 && char == formatter.escapePrefix && idx < len(layout) {
+			char, charLen = utf8.DecodeRuneInString(layout[idx:])
+			idx += charLen
+		}
+		literal.WriteRune(char)
+	}
+	// Unterminated quotes run until the end of the layout
+	return layoutToken{raw: layout, literal: literal.String(), escaped: true, unterminated: true}
 }
 
 func (formatter *DateHandlerString) TokenDescTokenFormatter(tokenFmt func(format string, a ...any) string) string {
@@ -101,6 +318,16 @@ func (formatter *DateHandlerString) TokenDescTokenFormatter(tokenFmt func(format
 		}
 
 	}
+
+	if len(formatter.standardLayouts) > 0 {
+		output.WriteString("\nStandard layouts used in place of a whole layout:\n")
+		for _, name := range slices.Sorted(maps.Keys(formatter.standardLayouts)) {
+			standard := formatter.standardLayouts[name]
+			output.WriteString(tokenFmt("%s: ", name))
+			output.WriteString(fmt.Sprintf("%s\n", standard.Desc))
+			output.WriteString(fmt.Sprintf("  layout: %s\n", standard.layout))
+		}
+	}
 	return output.String()
 }
 
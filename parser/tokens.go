@@ -1,5 +1,7 @@
 package parser
 
+import "unicode/utf8"
+
 type TokenGraphNode[T any] struct {
 	children map[rune]*TokenGraphNode[T]
 	value    T
@@ -27,3 +29,69 @@ func createTokenGraph[D any](stringMap *map[string]D) *TokenGraphNode[D] {
 
 	return &rootNode
 }
+
+// Section of a layout string that is either a recognised token or literal text
+type layoutToken struct {
+	// Text exactly as written in the layout including any prefix or escape characters
+	raw string
+	// Text output or matched as is for literal sections
+	literal string
+	// Definition of a recognised token; nil for literal sections
+	def *FormatToken[string]
+	// Literal section was written within escape characters
+	escaped bool
+	// Escaped section missing its closing character which runs to the end of the layout
+	unterminated bool
+	// Prefixed section that does not match any known token
+	unknown bool
+	// Character opening or closing a section that is optional when parsing
+	optionalStart bool
+	optionalEnd   bool
+}
+
+// Walks the token graph from the start of the string returning the longest token
+// found and its length in bytes
+func (node *TokenGraphNode[D]) longestToken(str string, isToken func(value D) bool) (D, int) {
+	var match D
+	matchLen := 0
+	for idx, char := range str {
+		child, hasToken := node.children[char]
+		if !hasToken {
+			break
+		}
+		node = child
+		if isToken(node.value) {
+			match = node.value
+			matchLen = idx + utf8.RuneLen(char)
+		}
+	}
+	return match, matchLen
+}
+
+// Walks the token graph from the start of the string for as long as it matches
+// returning the value of the node reached and the length in bytes of the text read
+//
+// The node reached may be part of a longer token rather than a token itself
+func (node *TokenGraphNode[D]) walk(str string) (D, int) {
+	walked := 0
+	for _, char := range str {
+		child, hasToken := node.children[char]
+		if !hasToken {
+			break
+		}
+		node = child
+		walked += utf8.RuneLen(char)
+	}
+	return node.value, walked
+}
+
+// Appends literal text to the tokens merging it into the previous section when that
+// is also unescaped literal text
+func appendLiteral(tokens []layoutToken, literal string) []layoutToken {
+	if last := len(tokens) - 1; last >= 0 && tokens[last].def == nil && !tokens[last].escaped && !tokens[last].unknown && !tokens[last].optionalStart && !tokens[last].optionalEnd {
+		tokens[last].raw += literal
+		tokens[last].literal = tokens[last].raw
+		return tokens
+	}
+	return append(tokens, layoutToken{raw: literal, literal: literal})
+}
@@ -0,0 +1,422 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Layout dialects that can be translated between
+var TranslateDialects = []string{"moment", "luxon", "strftime", "go"}
+
+// Tokens for the same date time field in each dialect where an empty string marks a
+// dialect without an equivalent
+type tokenEquivalent struct {
+	moment, luxon, strftime, golang string
+	// Moment token of the closest field used when a dialect has no equivalent e.g.
+	// a zero padded day in place of an unpadded one
+	similar string
+}
+
+func (equivalent tokenEquivalent) token(dialect string) string {
+	switch dialect {
+	case "moment":
+		return equivalent.moment
+	case "luxon":
+		return equivalent.luxon
+	case "strftime":
+		return equivalent.strftime
+	}
+	return equivalent.golang
+}
+
+// Equivalent tokens where the first entry for a token is used when it appears more
+// than once
+//
+// Go fractional seconds include the separator that must come before them
+var tokenEquivalents = []tokenEquivalent{
+	{moment: "YYYY", luxon: "yyyy", strftime: "%Y", golang: "2006"},
+	{moment: "Y", luxon: "y", similar: "YYYY"},
+	{moment: "YY", luxon: "yy", strftime: "%y", golang: "06"},
+	{moment: "Q", luxon: "q"},
+	{moment: "Qo", similar: "Q"},
+	{moment: "M", luxon: "L", golang: "1", similar: "MM"},
+	{moment: "MM", luxon: "LL", strftime: "%m", golang: "01"},
+	{moment: "Mo", similar: "M"},
+	{moment: "MMM", luxon: "LLL", strftime: "%b", golang: "Jan"},
+	{moment: "MMMM", luxon: "LLLL", strftime: "%B", golang: "January"},
+	{moment: "D", luxon: "d", golang: "2", similar: "DD"},
+	{moment: "DD", luxon: "dd", strftime: "%d", golang: "02"},
+	{moment: "Do", similar: "D"},
+	{strftime: "%e", golang: "_2", similar: "D"},
+	{moment: "DDD", luxon: "o", similar: "DDDD"},
+	{moment: "DDDD", luxon: "ooo", strftime: "%j", golang: "002"},
+	{moment: "DDDo", similar: "DDD"},
+	{golang: "__2", similar: "DDD"},
+	{moment: "d", strftime: "%w"},
+	{moment: "E", luxon: "c", strftime: "%u"},
+	{moment: "ddd", luxon: "ccc", strftime: "%a", golang: "Mon"},
+	{moment: "dddd", luxon: "cccc", strftime: "%A", golang: "Monday"},
+	{moment: "W", luxon: "W", similar: "WW"},
+	{moment: "WW", luxon: "WW", strftime: "%V"},
+	{luxon: "kkkk", strftime: "%G"},
+	{luxon: "kk", strftime: "%g"},
+	{moment: "H", luxon: "H", similar: "HH"},
+	{moment: "HH", luxon: "HH", strftime: "%H", golang: "15"},
+	{strftime: "%k", similar: "H"},
+	{moment: "h", luxon: "h", golang: "3", similar: "hh"},
+	{moment: "hh", luxon: "hh", strftime: "%I", golang: "03"},
+	{strftime: "%l", similar: "h"},
+	{moment: "m", luxon: "m", golang: "4", similar: "mm"},
+	{moment: "mm", luxon: "mm", strftime: "%M", golang: "04"},
+	{moment: "s", luxon: "s", golang: "5", similar: "ss"},
+	{moment: "ss", luxon: "ss", strftime: "%S", golang: "05"},
+	{moment: "S", luxon: "uuu", golang: ".0"},
+	{moment: "SS", luxon: "uu", golang: ".00"},
+	{moment: "SSS", luxon: "SSS", golang: ".000"},
+	{moment: "SSSS", golang: ".0000"},
+	{moment: "SSSSS", golang: ".00000"},
+	{moment: "SSSSSS", golang: ".000000"},
+	{moment: "SSSSSSS", golang: ".0000000"},
+	{moment: "SSSSSSSS", golang: ".00000000"},
+	{moment: "SSSSSSSSS", golang: ".000000000"},
+	{moment: "a", luxon: "a", strftime: "%p", golang: "pm"},
+	{moment: "A", golang: "PM", similar: "a"},
+	{moment: "N", luxon: "G"},
+	{moment: "NNNN", luxon: "GG"},
+	{moment: "X", luxon: "X", strftime: "%s"},
+	{moment: "x", luxon: "x"},
+	{moment: "Z", luxon: "ZZ", golang: "-07:00"},
+	{moment: "ZZ", luxon: "ZZZ", strftime: "%z", golang: "-0700"},
+	{moment: "z", luxon: "ZZZZ", strftime: "%Z", golang: "MST"},
+	{luxon: "z"},
+	{moment: "Z", luxon: "ZZ", golang: "Z07:00"},
+	{moment: "ZZ", luxon: "ZZZ", strftime: "%z", golang: "Z0700"},
+	{golang: "-07", similar: "ZZ"},
+	{golang: "Z07", similar: "ZZ"},
+	{golang: "-07:00:00", similar: "Z"},
+	{golang: "-070000", similar: "ZZ"},
+	{golang: "Z07:00:00", similar: "Z"},
+	{golang: "Z070000", similar: "ZZ"},
+	// strftime tokens with glibc flags, which are only ever looked up from strftime as
+	// the unflagged tokens come first
+	{moment: "D", luxon: "d", strftime: "%-d", golang: "2"},
+	{moment: "D", luxon: "d", strftime: "%-e", golang: "2"},
+	{strftime: "%_d", golang: "_2", similar: "D"},
+	{moment: "DD", luxon: "dd", strftime: "%0e", golang: "02"},
+	{moment: "M", luxon: "L", strftime: "%-m", golang: "1"},
+	{moment: "DDD", luxon: "o", strftime: "%-j", similar: "DDDD"},
+	{moment: "W", luxon: "W", strftime: "%-V", similar: "WW"},
+	{moment: "H", luxon: "H", strftime: "%-H", similar: "HH"},
+	{moment: "H", luxon: "H", strftime: "%-k", similar: "HH"},
+	{strftime: "%_H", similar: "H"},
+	{moment: "h", luxon: "h", strftime: "%-I", golang: "3"},
+	{moment: "h", luxon: "h", strftime: "%-l", golang: "3"},
+	{strftime: "%_I", similar: "h"},
+	{moment: "m", luxon: "m", strftime: "%-M", golang: "4"},
+	{moment: "s", luxon: "s", strftime: "%-S", golang: "5"},
+}
+
+// strftime tokens made up of other tokens
+var strftimeComposites = map[string]string{
+	"%F": "%Y-%m-%d",
+	"%T": "%H:%M:%S",
+	"%R": "%H:%M",
+	"%D": "%m/%d/%y",
+	"%r": "%I:%M:%S %p",
+	"%v": "%e-%b-%Y",
+}
+
+// strftime tokens that only ever output the same text
+var strftimeLiterals = map[string]string{
+	"%%": "%",
+	"%n": "\n",
+	"%t": "\t",
+}
+
+// Section of a layout in any dialect that is either a token or literal text
+type dialectToken struct {
+	token   string
+	literal string
+	// Token that isn't known in the dialect
+	unknown bool
+	// Original token when its flags have no equivalent and were left out e.g. '%^a'
+	flagged string
+}
+
+// Translates a layout from one dialect to another e.g. the moment layout 'Do MMM YYYY'
+// to the strftime layout '%d %b %Y'
+//
+// Tokens without an exact equivalent are replaced by the closest field when there is
+// one, otherwise they are kept as literal text. Each approximation is described in the
+// returned warnings
+func Translate(layout, from, to string) (string, []string, error) {
+	for _, dialect := range []string{from, to} {
+		if !slices.Contains(TranslateDialects, dialect) {
+			return "", nil, fmt.Errorf("%q is not a dialect that can be translated, use %s", dialect, strings.Join(TranslateDialects, ", "))
+		}
+	}
+
+	var warnings []string
+	var output strings.Builder
+	for _, token := range tokeniseDialect(layout, from) {
+		switch {
+		case token.unknown:
+			warnings = append(warnings, fmt.Sprintf("%q is not a known %s token and was kept as literal text", token.token, from))
+			writeDialectLiteral(&output, token.token, to, &warnings)
+		case token.token == "":
+			writeDialectLiteral(&output, token.literal, to, &warnings)
+		default:
+			if token.flagged != "" {
+				warnings = append(warnings, fmt.Sprintf("The flags of %q have no equivalent in %s and were left out", token.flagged, to))
+			}
+			translated, warning := translateToken(token.token, from, to)
+			if warning != "" {
+				warnings = append(warnings, warning)
+			}
+			if translated == "" {
+				literal := token.token
+				if from == "go" && literal[0] == '.' {
+					// The separator before Go fractional seconds is already literal text
+					literal = literal[1:]
+				}
+				writeDialectLiteral(&output, literal, to, &warnings)
+				continue
+			}
+			writeDialectToken(&output, translated, to, &warnings)
+		}
+	}
+
+	return output.String(), warnings, nil
+}
+
+// Finds the equivalent of a token returning an empty string and a warning when there
+// is none
+func translateToken(token, from, to string) (string, string) {
+	idx := slices.IndexFunc(tokenEquivalents, func(equivalent tokenEquivalent) bool {
+		return equivalent.token(from) == token
+	})
+	if idx == -1 {
+		return "", fmt.Sprintf("%q has no equivalent in %s and was kept as literal text", token, to)
+	}
+
+	equivalent := tokenEquivalents[idx]
+	if translated := equivalent.token(to); translated != "" {
+		return translated, ""
+	}
+
+	// Closest fields are followed until one exists in the target dialect
+	for range tokenEquivalents {
+		if equivalent.similar == "" {
+			break
+		}
+		idx := slices.IndexFunc(tokenEquivalents, func(other tokenEquivalent) bool {
+			return other.moment == equivalent.similar
+		})
+		if idx == -1 {
+			break
+		}
+		equivalent = tokenEquivalents[idx]
+		if translated := equivalent.token(to); translated != "" {
+			return translated, fmt.Sprintf("%q has no exact equivalent in %s and was replaced with %q", token, to, translated)
+		}
+	}
+
+	return "", fmt.Sprintf("%q has no equivalent in %s and was kept as literal text", token, to)
+}
+
+// Splits a layout into tokens and literal text where tokens are written in their
+// canonical form rather than any alias
+func tokeniseDialect(layout, dialect string) []dialectToken {
+	switch dialect {
+	case "moment":
+		return tokeniseStringDialect(&MomentJs, tokenMapMoment, layout)
+	case "luxon":
+		return tokeniseStringDialect(&Luxon, tokenMapLuxon, layout)
+	case "strftime":
+		return tokeniseStrftimeDialect(layout)
+	}
+	return tokeniseGoDialect(layout)
+}
+
+// Looks up the token an alias belongs to, returning tokens that aren't aliases as is
+func canonicalToken(tokenMap TokenMap, token, prefix string) string {
+	for canonical, def := range tokenMap {
+		if slices.Contains(def.aliases, strings.TrimPrefix(token, prefix)) {
+			return prefix + canonical
+		}
+	}
+	return token
+}
+
+func tokeniseStringDialect(formatter *DateHandlerString, tokenMap TokenMap, layout string) []dialectToken {
+	var tokens []dialectToken
+	for _, token := range formatter.tokenise(layout) {
+		if token.def == nil {
+			tokens = append(tokens, dialectToken{literal: token.literal})
+			continue
+		}
+		tokens = append(tokens, dialectToken{token: canonicalToken(tokenMap, token.raw, "")})
+	}
+	return tokens
+}
+
+func tokeniseStrftimeDialect(layout string) []dialectToken {
+	var tokens []dialectToken
+	for _, token := range GoStrptime.tokenise(layout) {
+		switch {
+		case token.unknown:
+			tokens = append(tokens, dialectToken{token: token.raw, unknown: true})
+		case token.def == nil:
+			tokens = append(tokens, dialectToken{literal: token.literal})
+		default:
+			tokens = append(tokens, strftimeDialectToken(token.raw)...)
+		}
+	}
+	return tokens
+}
+
+func strftimeDialectToken(raw string) []dialectToken {
+	token := strings.TrimLeftFunc(raw[1:], func(char rune) bool {
+		_, isFlag := strftimeFlags[char]
+		return isFlag
+	})
+	flags := raw[1 : len(raw)-len(token)]
+	token = canonicalToken(tokenMapStrftime, "%"+token, "%")
+
+	switch {
+	case strftimeComposites[token] != "":
+		return tokeniseStrftimeDialect(strftimeComposites[token])
+	case strftimeLiterals[token] != "":
+		return []dialectToken{{literal: strftimeLiterals[token]}}
+	case flags == "":
+		return []dialectToken{{token: token}}
+	}
+
+	// Flagged tokens with an equivalent of their own keep their flags
+	flagged := "%" + flags + token[1:]
+	if slices.ContainsFunc(tokenEquivalents, func(equivalent tokenEquivalent) bool { return equivalent.strftime == flagged }) {
+		return []dialectToken{{token: flagged}}
+	}
+	return []dialectToken{{token: token, flagged: raw}}
+}
+
+// Tokens recognised within Go layouts other than fractional seconds, which are matched
+// separately as a '.' or ',' followed by zeros or nines
+var goLayoutTokens = []string{
+	"January", "Jan", "Monday", "Mon", "MST", "PM", "pm",
+	"2006", "002", "__2", "_2", "01", "02", "03", "04", "05", "06", "15", "1", "2", "3", "4", "5",
+	"Z07:00:00", "Z070000", "Z07:00", "Z0700", "Z07",
+	"-07:00:00", "-070000", "-07:00", "-0700", "-07",
+}
+
+// Length of the Go layout token at the start of the layout or 0 for literal text
+func goTokenLength(layout string) int {
+	if len(layout) > 1 && (layout[0] == '.' || layout[0] == ',') && (layout[1] == '0' || layout[1] == '9') {
+		end := 1
+		for end < len(layout) && layout[end] == layout[1] {
+			end++
+		}
+		if end == len(layout) || !unicode.IsDigit(rune(layout[end])) {
+			return end
+		}
+	}
+
+	longest := 0
+	for _, token := range goLayoutTokens {
+		if strings.HasPrefix(layout, token) && len(token) > longest {
+			longest = len(token)
+		}
+	}
+	return longest
+}
+
+func tokeniseGoDialect(layout string) []dialectToken {
+	var tokens []dialectToken
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, dialectToken{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for idx := 0; idx < len(layout); {
+		tokenLen := goTokenLength(layout[idx:])
+		if tokenLen == 0 {
+			literal.WriteByte(layout[idx])
+			idx++
+			continue
+		}
+
+		token := layout[idx : idx+tokenLen]
+		if token[0] == '.' || token[0] == ',' {
+			// The separator is kept as literal text before the fractional seconds
+			literal.WriteByte(token[0])
+			token = "." + strings.ReplaceAll(token[1:], "9", "0")
+		}
+		flush()
+		tokens = append(tokens, dialectToken{token: token})
+		idx += tokenLen
+	}
+	flush()
+
+	return tokens
+}
+
+// Writes a token in the target dialect where Go fractional seconds take the place of
+// any separator before them
+func writeDialectToken(output *strings.Builder, token, dialect string, warnings *[]string) {
+	if dialect != "go" || token[0] != '.' {
+		output.WriteString(token)
+		return
+	}
+
+	written := output.String()
+	if strings.HasSuffix(written, ".") || strings.HasSuffix(written, ",") {
+		output.WriteString(token[1:])
+		return
+	}
+	*warnings = append(*warnings, "Go requires a '.' or ',' before fractional seconds so a '.' was added")
+	output.WriteString(token)
+}
+
+// Writes literal text escaping it as needed for the target dialect
+func writeDialectLiteral(output *strings.Builder, literal, dialect string, warnings *[]string) {
+	if literal == "" {
+		return
+	}
+
+	switch dialect {
+	case "moment":
+		if strings.IndexFunc(literal, unicode.IsLetter) == -1 && !strings.ContainsAny(literal, "[]") {
+			output.WriteString(literal)
+			return
+		}
+		output.WriteString("[" + strings.NewReplacer("[", "", "]", "").Replace(literal) + "]")
+		if strings.ContainsAny(literal, "[]") {
+			*warnings = append(*warnings, fmt.Sprintf("Moment cannot escape the brackets in %q so they were removed", literal))
+		}
+	case "luxon":
+		if strings.IndexFunc(literal, unicode.IsLetter) == -1 && !strings.Contains(literal, "'") {
+			output.WriteString(literal)
+			return
+		}
+		output.WriteString("'" + strings.ReplaceAll(literal, "'", "") + "'")
+		if strings.Contains(literal, "'") {
+			*warnings = append(*warnings, fmt.Sprintf("Luxon cannot escape the quotes in %q so they were removed", literal))
+		}
+	case "strftime":
+		output.WriteString(strings.ReplaceAll(literal, "%", "%%"))
+	default:
+		// Go layouts cannot escape text so literals that look like tokens are reported
+		for idx := 0; idx < len(literal); idx++ {
+			if tokenLen := goTokenLength(literal[idx:]); tokenLen > 0 {
+				*warnings = append(*warnings, fmt.Sprintf("Go layouts cannot escape %q within the literal text %q", literal[idx:idx+tokenLen], literal))
+				break
+			}
+		}
+		output.WriteString(literal)
+	}
+}
@@ -0,0 +1,51 @@
+package parser
+
+import "testing"
+
+func TestTranslate(t *testing.T) {
+	scenarios := []struct {
+		layout   string
+		from     string
+		to       string
+		want     string
+		warnings int
+	}{
+		{layout: "YYYY-MM-DD HH:mm:ss", from: "moment", to: "strftime", want: "%Y-%m-%d %H:%M:%S"},
+		{layout: "YYYY-MM-DDTHH:mm:ss.SSSZ", from: "moment", to: "go", want: "2006-01-02T15:04:05.000-07:00"},
+		{layout: "Do MMM YYYY", from: "moment", to: "strftime", want: "%d %b %Y", warnings: 1},
+		{layout: "[Week] W, dddd", from: "moment", to: "luxon", want: "'Week' W, cccc"},
+		{layout: "%F %T %%", from: "strftime", to: "moment", want: "YYYY-MM-DD HH:mm:ss %"},
+		{layout: "%h %e", from: "strftime", to: "luxon", want: "LLL d", warnings: 1},
+		{layout: "Mon, 02 Jan 2006 15:04:05 MST", from: "go", to: "moment", want: "ddd, DD MMM YYYY HH:mm:ss z"},
+		{layout: "2006-01-02 15:04:05,999", from: "go", to: "luxon", want: "yyyy-LL-dd HH:mm:ss,SSS"},
+		{layout: "'Day' o 'of' yyyy", from: "luxon", to: "go", want: "Day 002 of 2006", warnings: 1},
+		{layout: "'Monthly' yyyy", from: "luxon", to: "go", want: "Monthly 2006", warnings: 1},
+		{layout: "HH:mm:ssSSS", from: "luxon", to: "go", want: "15:04:05.000", warnings: 1},
+		{layout: "Q YYYY", from: "moment", to: "go", want: "Q 2006", warnings: 1},
+		{layout: "15:04:05.000", from: "go", to: "strftime", want: "%H:%M:%S.000", warnings: 1},
+		{layout: "%Y %% [x] %-d", from: "strftime", to: "moment", want: "YYYY %[ x ]D", warnings: 1},
+		{layout: "%-d/%-m %-H:%M %^a", from: "strftime", to: "go", want: "2/1 15:04 Mon", warnings: 2},
+	}
+
+	for _, testCase := range scenarios {
+		t.Run(testCase.layout, func(t *testing.T) {
+			t.Parallel()
+			got, warnings, err := Translate(testCase.layout, testCase.from, testCase.to)
+			if err != nil {
+				t.Fatalf("Failed to translate '%s'\n%s", testCase.layout, err)
+			}
+			if got != testCase.want {
+				t.Errorf("Expected '%s' from %s to translate to %s as '%s'\nGot: '%s'", testCase.layout, testCase.from, testCase.to, testCase.want, got)
+			}
+			if len(warnings) != testCase.warnings {
+				t.Errorf("Expected %d warnings translating '%s'\nGot: %q", testCase.warnings, testCase.layout, warnings)
+			}
+		})
+	}
+}
+
+func TestTranslateInvalid(t *testing.T) {
+	if _, _, err := Translate("YYYY", "moment", "java"); err == nil {
+		t.Errorf("Expected translating to an unknown dialect to fail")
+	}
+}